@@ -0,0 +1,91 @@
+// Package io provides readers and writers for the Conway's Game of Life
+// pattern formats game-of-life understands: the original "(x, y)"
+// coordinate-pair format, Life 1.06, Life 1.05, and RLE. Each format is a
+// Codec; Decode auto-detects which one applies to a given input.
+package io
+
+import (
+	"fmt"
+	ioPkg "io"
+	"strings"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// Metadata is the pattern information carried alongside a World that isn't
+// part of the cell grid itself: its name, author, free-form comments, and
+// the rule string it was declared with (if any).
+type Metadata struct {
+	Name     string
+	Author   string
+	Comments []string
+	Rule     string
+}
+
+// Codec reads and writes one pattern format
+type Codec interface {
+	// Name identifies the format, e.g. "life106", "life105", or "rle"
+	Name() string
+
+	// Decode parses lines into a World seeded within bounds, along with any
+	// metadata the format carries
+	Decode(lines []string, bounds world.Bounds, wraparound bool) (*world.World, Metadata, error)
+
+	// Encode writes w in this format to out, alongside meta
+	Encode(w *world.World, meta Metadata, out ioPkg.Writer) error
+}
+
+// Sets state at (x, y) after checking it falls within w's bounds; shared
+// by every format's decoder
+func setChecked(w *world.World, x int64, y int64, state int) error {
+	if x < w.Bounds.MinX || x > w.Bounds.MaxX {
+		return fmt.Errorf("X-coordinate %d outside the world bounds %d..%d", x, w.Bounds.MinX, w.Bounds.MaxX)
+	}
+
+	if y < w.Bounds.MinY || y > w.Bounds.MaxY {
+		return fmt.Errorf("Y-coordinate %d outside the world bounds %d..%d", y, w.Bounds.MinY, w.Bounds.MaxY)
+	}
+
+	w.SetRaw(x, y, state)
+
+	return nil
+}
+
+// ByName returns the Codec registered under name ("life106", "life105", or
+// "rle"), or Life106Codec if name isn't recognized
+func ByName(name string) Codec {
+	switch name {
+	case "life105":
+		return Life105Codec{}
+	case "rle":
+		return RLECodec{}
+	default:
+		return Life106Codec{}
+	}
+}
+
+// Detect inspects the leading lines of an input and picks the Codec that
+// should parse it: an explicit "#Life 1.05" header, a run of "#" comment
+// lines followed by an RLE "x = ..." header, or the original coordinate-pair
+// format (decoded by Life106Codec, which also accepts a "#Life 1.06" header).
+func Detect(lines []string) Codec {
+	if len(lines) == 0 {
+		return Life106Codec{}
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "#Life 1.05") {
+		return Life105Codec{}
+	}
+
+	index := 0
+
+	for index < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[index]), "#") {
+		index++
+	}
+
+	if index < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[index]), "x") {
+		return RLECodec{}
+	}
+
+	return Life106Codec{}
+}
@@ -0,0 +1,237 @@
+package io
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// liveCoords returns every live (state 1) coordinate in w, sorted for
+// deterministic comparison
+func liveCoords(w *world.World) []world.Coord {
+	coords := []world.Coord{}
+
+	w.Each(func(x, y int64, state int) {
+		if state == 1 {
+			coords = append(coords, world.Coord{X: x, Y: y})
+		}
+	})
+
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].X != coords[j].X {
+			return coords[i].X < coords[j].X
+		}
+
+		return coords[i].Y < coords[j].Y
+	})
+
+	return coords
+}
+
+func assertSameLiveCoords(t *testing.T, got, want *world.World) {
+	t.Helper()
+
+	gotCoords, wantCoords := liveCoords(got), liveCoords(want)
+
+	if len(gotCoords) != len(wantCoords) {
+		t.Fatalf("got %d live cells, want %d (%v vs %v)", len(gotCoords), len(wantCoords), gotCoords, wantCoords)
+	}
+
+	for i := range wantCoords {
+		if gotCoords[i] != wantCoords[i] {
+			t.Errorf("live cell %d = %v, want %v", i, gotCoords[i], wantCoords[i])
+		}
+	}
+}
+
+func gliderWorld(bounds world.Bounds) *world.World {
+	w := world.New(bounds, false)
+
+	for _, coord := range []world.Coord{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+		w.SetRaw(coord.X, coord.Y, 1)
+	}
+
+	return w
+}
+
+func TestLife106DecodeCoordinatePairs(t *testing.T) {
+	bounds := world.Bounds{MinX: -10, MaxX: 10, MinY: -10, MaxY: 10}
+
+	decoded, _, err := (Life106Codec{}).Decode([]string{"(1, 0)", "(2, 1)", "(0, 2)", "(1, 2)", "(2, 2)"}, bounds, false)
+
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	assertSameLiveCoords(t, decoded, gliderWorld(bounds))
+}
+
+func TestLife106Encode(t *testing.T) {
+	bounds := world.Bounds{MinX: -10, MaxX: 10, MinY: -10, MaxY: 10}
+
+	var buf bytes.Buffer
+
+	if err := (Life106Codec{}).Encode(gliderWorld(bounds), Metadata{}, &buf); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	want := "#Life 1.06\n0 2\n1 0\n1 2\n2 1\n2 2\n"
+
+	if buf.String() != want {
+		t.Errorf("Encode output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestLife106DecodeRejectsMalformedLine(t *testing.T) {
+	bounds := world.Bounds{MinX: -10, MaxX: 10, MinY: -10, MaxY: 10}
+
+	if _, _, err := (Life106Codec{}).Decode([]string{"1, 2"}, bounds, false); err == nil {
+		t.Error("Decode of a line missing parentheses succeeded, want error")
+	}
+}
+
+func TestLife105RoundTrip(t *testing.T) {
+	bounds := world.Bounds{MinX: -10, MaxX: 10, MinY: -10, MaxY: 10}
+	original := gliderWorld(bounds)
+	meta := Metadata{Comments: []string{"a glider"}, Rule: "B3/S23"}
+
+	var buf bytes.Buffer
+
+	if err := (Life105Codec{}).Encode(original, meta, &buf); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	decoded, decodedMeta, err := (Life105Codec{}).Decode(strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), bounds, false)
+
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	assertSameLiveCoords(t, decoded, original)
+
+	// B3/S23 is the default rule, so Encode omits the #R line entirely
+	if decodedMeta.Rule != "" {
+		t.Errorf("Rule = %q, want empty (default rule is omitted)", decodedMeta.Rule)
+	}
+
+	if len(decodedMeta.Comments) != 1 || decodedMeta.Comments[0] != "a glider" {
+		t.Errorf("Comments = %v, want [a glider]", decodedMeta.Comments)
+	}
+}
+
+func TestLife105RoundTripNonDefaultRule(t *testing.T) {
+	bounds := world.Bounds{MinX: -10, MaxX: 10, MinY: -10, MaxY: 10}
+	original := gliderWorld(bounds)
+	meta := Metadata{Rule: "B36/S23"}
+
+	var buf bytes.Buffer
+
+	if err := (Life105Codec{}).Encode(original, meta, &buf); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	_, decodedMeta, err := (Life105Codec{}).Decode(strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), bounds, false)
+
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if decodedMeta.Rule != "B36/S23" {
+		t.Errorf("Rule = %q, want B36/S23", decodedMeta.Rule)
+	}
+}
+
+// rlePatternWorld returns a World seeded with a glider pattern whose live
+// bounds already sit at MinX=0, MaxY=0, since plain RLE carries no
+// absolute offset: Decode always places the body's top-left corner at
+// (0, 0), so only a pattern anchored there round-trips to identical
+// coordinates.
+func rlePatternWorld(bounds world.Bounds) *world.World {
+	w := world.New(bounds, false)
+
+	for _, coord := range []world.Coord{{X: 1, Y: -2}, {X: 2, Y: -1}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}} {
+		w.SetRaw(coord.X, coord.Y, 1)
+	}
+
+	return w
+}
+
+func TestRLERoundTrip(t *testing.T) {
+	bounds := world.Bounds{MinX: -10, MaxX: 10, MinY: -10, MaxY: 10}
+	original := rlePatternWorld(bounds)
+	meta := Metadata{Name: "Glider", Rule: "B3/S23"}
+
+	var buf bytes.Buffer
+
+	if err := (RLECodec{}).Encode(original, meta, &buf); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	decoded, decodedMeta, err := (RLECodec{}).Decode(strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), bounds, false)
+
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	assertSameLiveCoords(t, decoded, original)
+
+	if decodedMeta.Name != "Glider" {
+		t.Errorf("Name = %q, want Glider", decodedMeta.Name)
+	}
+
+	if decodedMeta.Rule != "B3/S23" {
+		t.Errorf("Rule = %q, want B3/S23", decodedMeta.Rule)
+	}
+}
+
+func TestRLEDecodeMultiStateToken(t *testing.T) {
+	bounds := world.Bounds{MinX: 0, MaxX: 5, MinY: 0, MaxY: 5}
+	lines := []string{"x = 2, y = 1, rule = B3/S23/C28", "opA!"}
+
+	decoded, _, err := (RLECodec{}).Decode(lines, bounds, false)
+
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if got := decoded.Get(0, 0); got != 1 {
+		t.Errorf("Get(0, 0) = %d, want 1", got)
+	}
+
+	if got := decoded.Get(1, 0); got != 26 {
+		t.Errorf("Get(1, 0) = %d, want 26 (pA decodes to state 26)", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"life105", []string{"#Life 1.05", "#P 0 0", "*"}, "life105"},
+		{"life106", []string{"#Life 1.06", "1 2"}, "life106"},
+		{"rle", []string{"#N Glider", "x = 3, y = 3, rule = B3/S23", "bob$2bo$3o!"}, "rle"},
+		{"coordinate pairs", []string{"(1, 2)", "(3, 4)"}, "life106"},
+		{"empty", []string{}, "life106"},
+	}
+
+	for _, c := range cases {
+		if got := Detect(c.lines).Name(); got != c.want {
+			t.Errorf("%s: Detect().Name() = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestByName(t *testing.T) {
+	cases := map[string]string{"life105": "life105", "rle": "rle", "life106": "life106", "unknown": "life106"}
+
+	for name, want := range cases {
+		if got := ByName(name).Name(); got != want {
+			t.Errorf("ByName(%s).Name() = %s, want %s", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package io
+
+import (
+	"fmt"
+	ioPkg "io"
+	"strconv"
+	"strings"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// Life105Codec reads and writes the Life 1.05 format: a "#Life 1.05"
+// header, optional "#D"/"#R" metadata lines, and one or more "#P x y"
+// blocks of "."/"*" grid rows.
+type Life105Codec struct{}
+
+func (Life105Codec) Name() string {
+	return "life105"
+}
+
+func (Life105Codec) Decode(lines []string, bounds world.Bounds, wraparound bool) (*world.World, Metadata, error) {
+	w := world.New(bounds, wraparound)
+	meta := Metadata{}
+
+	var blockX, blockY int64
+	var row int64
+	inBlock := false
+
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#P") {
+			fields := strings.Fields(trimmed)
+
+			if len(fields) != 3 {
+				return nil, Metadata{}, fmt.Errorf("malformed Life 1.05 #P line: %s", line)
+			}
+
+			x, err := strconv.ParseInt(fields[1], 10, 64)
+
+			if err != nil {
+				return nil, Metadata{}, fmt.Errorf("unable to parse #P x-offset %s: %w", fields[1], err)
+			}
+
+			y, err := strconv.ParseInt(fields[2], 10, 64)
+
+			if err != nil {
+				return nil, Metadata{}, fmt.Errorf("unable to parse #P y-offset %s: %w", fields[2], err)
+			}
+
+			blockX, blockY = x, y
+			row = 0
+			inBlock = true
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#R") {
+			fields := strings.Fields(trimmed)
+
+			if len(fields) == 2 {
+				meta.Rule = fields[1]
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#D") {
+			meta.Comments = append(meta.Comments, strings.TrimSpace(trimmed[2:]))
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if !inBlock {
+			return nil, Metadata{}, fmt.Errorf("Life 1.05 grid row encountered before a #P block header: %s", line)
+		}
+
+		for col, cell := range trimmed {
+			switch cell {
+			case '*':
+				if err := setChecked(w, blockX+int64(col), blockY-row, 1); err != nil {
+					return nil, Metadata{}, err
+				}
+			case '.':
+				// dead cell; nothing to add
+			default:
+				return nil, Metadata{}, fmt.Errorf("unexpected Life 1.05 cell character %q", cell)
+			}
+		}
+
+		row++
+	}
+
+	return w, meta, nil
+}
+
+func (Life105Codec) Encode(w *world.World, meta Metadata, out ioPkg.Writer) error {
+	fmt.Fprintln(out, "#Life 1.05")
+
+	for _, comment := range meta.Comments {
+		fmt.Fprintf(out, "#D %s\n", comment)
+	}
+
+	if meta.Rule != "" && meta.Rule != "B3/S23" {
+		fmt.Fprintf(out, "#R %s\n", meta.Rule)
+	}
+
+	bounds, empty := w.LiveBounds()
+
+	if empty {
+		return nil
+	}
+
+	fmt.Fprintf(out, "#P %d %d\n", bounds.MinX, bounds.MaxY)
+
+	for y := bounds.MaxY; y >= bounds.MinY; y-- {
+		var line strings.Builder
+
+		for x := bounds.MinX; x <= bounds.MaxX; x++ {
+			if w.Get(x, y) == 1 {
+				line.WriteByte('*')
+			} else {
+				line.WriteByte('.')
+			}
+		}
+
+		fmt.Fprintln(out, line.String())
+	}
+
+	return nil
+}
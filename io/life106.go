@@ -0,0 +1,101 @@
+package io
+
+import (
+	"fmt"
+	ioPkg "io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// Life106Codec reads the original "(x, y)" coordinate-pair format (one
+// organism per line) as well as "#Life 1.06", and writes "#Life 1.06".
+type Life106Codec struct{}
+
+func (Life106Codec) Name() string {
+	return "life106"
+}
+
+func (Life106Codec) Decode(lines []string, bounds world.Bounds, wraparound bool) (*world.World, Metadata, error) {
+	w := world.New(bounds, wraparound)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		lineLength := len(trimmed) - 1
+
+		var coordinates []string
+
+		if trimmed[0] == '(' {
+			if trimmed[lineLength] != ')' {
+				return nil, Metadata{}, fmt.Errorf("error reading right parenthesis: %q", trimmed[lineLength])
+			}
+
+			coordinates = strings.Split(trimmed[1:lineLength], ",")
+		} else {
+			// Life 1.06 body row: "x y"
+			coordinates = strings.Fields(trimmed)
+
+			if len(coordinates) != 2 {
+				return nil, Metadata{}, fmt.Errorf("error reading left parenthesis: %q", trimmed[0])
+			}
+		}
+
+		coordX, err := strconv.ParseInt(strings.TrimSpace(coordinates[0]), 10, 64)
+
+		if err != nil {
+			return nil, Metadata{}, fmt.Errorf("unable to parse X-coordinate integer from input string %s: %w", coordinates[0], err)
+		}
+
+		coordY, err := strconv.ParseInt(strings.TrimSpace(coordinates[1]), 10, 64)
+
+		if err != nil {
+			return nil, Metadata{}, fmt.Errorf("unable to parse Y-coordinate integer from input string %s: %w", coordinates[1], err)
+		}
+
+		if err := setChecked(w, coordX, coordY, 1); err != nil {
+			return nil, Metadata{}, fmt.Errorf("input error: %w", err)
+		}
+	}
+
+	return w, Metadata{}, nil
+}
+
+func (Life106Codec) Encode(w *world.World, meta Metadata, out ioPkg.Writer) error {
+	fmt.Fprintln(out, "#Life 1.06")
+
+	coordXs := []int64{}
+	byX := make(map[int64][]int64)
+
+	w.Each(func(x, y int64, state int) {
+		if state != 1 {
+			return
+		}
+
+		if _, ok := byX[x]; !ok {
+			coordXs = append(coordXs, x)
+		}
+
+		byX[x] = append(byX[x], y)
+	})
+
+	sort.Slice(coordXs, func(i, j int) bool { return coordXs[i] < coordXs[j] })
+
+	for _, x := range coordXs {
+		ys := byX[x]
+
+		sort.Slice(ys, func(i, j int) bool { return ys[i] < ys[j] })
+
+		for _, y := range ys {
+			fmt.Fprintf(out, "%d %d\n", x, y)
+		}
+	}
+
+	return nil
+}
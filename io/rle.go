@@ -0,0 +1,278 @@
+package io
+
+import (
+	"fmt"
+	ioPkg "io"
+	"strconv"
+	"strings"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// RLECodec reads and writes the RLE format: optional "#C"/"#N"/"#O"
+// comment lines, an "x = W, y = H, rule = B.../S..." header, and a
+// run-length encoded body of tokens terminated by "!". Each token is "b"
+// (dead), "o" (state 1), a single letter A-X (states 2-25), or a
+// two-letter pA-yX code (states 26-265, Golly's multi-state scheme for
+// Generations rules), or "$" to move to the next row.
+type RLECodec struct{}
+
+func (RLECodec) Name() string {
+	return "rle"
+}
+
+func (RLECodec) Decode(lines []string, bounds world.Bounds, wraparound bool) (*world.World, Metadata, error) {
+	w := world.New(bounds, wraparound)
+	meta := Metadata{}
+
+	index := 0
+
+	for index < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[index]), "#") {
+		parseRLEComment(lines[index], &meta)
+		index++
+	}
+
+	if index >= len(lines) {
+		return nil, Metadata{}, fmt.Errorf("RLE input is missing its header line")
+	}
+
+	if err := parseRLEHeader(lines[index], &meta); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	body := []rune(strings.ReplaceAll(strings.Join(lines[index+1:], ""), " ", ""))
+
+	var row, col int64
+	count := ""
+
+	for i := 0; i < len(body); {
+		token := body[i]
+
+		switch {
+		case token >= '0' && token <= '9':
+			count += string(token)
+			i++
+		case token == 'b':
+			run, err := parseRLECount(count)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			col += run
+			count = ""
+			i++
+		case token == '$':
+			run, err := parseRLECount(count)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			row += run
+			col = 0
+			count = ""
+			i++
+		case token == '!':
+			count = ""
+			i++
+		default:
+			state, consumed, err := parseRLEStateToken(body[i:])
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			run, err := parseRLECount(count)
+
+			if err != nil {
+				return nil, Metadata{}, err
+			}
+
+			for offset := int64(0); offset < run; offset++ {
+				if err := setChecked(w, col+offset, -row, state); err != nil {
+					return nil, Metadata{}, err
+				}
+			}
+
+			col += run
+			count = ""
+			i += consumed
+		}
+	}
+
+	return w, meta, nil
+}
+
+// Parses a run-length count, defaulting to 1 when none was given
+func parseRLECount(count string) (int64, error) {
+	if count == "" {
+		return 1, nil
+	}
+
+	value, err := strconv.ParseInt(count, 10, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse RLE run count %s: %w", count, err)
+	}
+
+	return value, nil
+}
+
+// Parses a single RLE comment line into pattern metadata
+func parseRLEComment(line string, meta *Metadata) {
+	trimmed := strings.TrimSpace(line)
+
+	switch {
+	case strings.HasPrefix(trimmed, "#N"):
+		meta.Name = strings.TrimSpace(trimmed[2:])
+	case strings.HasPrefix(trimmed, "#C"):
+		meta.Comments = append(meta.Comments, strings.TrimSpace(trimmed[2:]))
+	case strings.HasPrefix(trimmed, "#O"):
+		meta.Author = strings.TrimSpace(trimmed[2:])
+	}
+}
+
+// Parses the RLE "x = W, y = H, rule = B.../S..." header line; only the
+// rule is used, since coordinates are derived directly from the body
+func parseRLEHeader(line string, meta *Metadata) error {
+	for _, field := range strings.Split(line, ",") {
+		keyValue := strings.SplitN(field, "=", 2)
+
+		if len(keyValue) != 2 {
+			continue
+		}
+
+		if key := strings.TrimSpace(keyValue[0]); key == "rule" {
+			meta.Rule = strings.TrimSpace(keyValue[1])
+		}
+	}
+
+	return nil
+}
+
+// Parses a single- or double-letter RLE state token (e.g. "o" -> 1, "B" ->
+// 3, "pC" -> 28), returning the state and how many runes it consumed
+func parseRLEStateToken(token []rune) (int, int, error) {
+	if token[0] == 'o' {
+		return 1, 1, nil
+	}
+
+	if token[0] >= 'A' && token[0] <= 'X' {
+		return int(token[0]-'A') + 2, 1, nil
+	}
+
+	if token[0] >= 'p' && token[0] <= 'y' {
+		if len(token) < 2 || token[1] < 'A' || token[1] > 'X' {
+			return 0, 0, fmt.Errorf("malformed multi-state RLE token starting with %q", token[0])
+		}
+
+		return 25 + int(token[0]-'p')*24 + int(token[1]-'A') + 1, 2, nil
+	}
+
+	return 0, 0, fmt.Errorf("unexpected RLE token %q", token[0])
+}
+
+// Formats a cell's state as its RLE token: "b" for dead, "o" for state 1,
+// a single letter A-X for states 2-25, or a two-letter pA-yX code for
+// states 26-265 (Golly's multi-state scheme; see parseRLEStateToken)
+func stateToRLEToken(state int) (string, error) {
+	if state == 0 {
+		return "b", nil
+	}
+
+	if state == 1 {
+		return "o", nil
+	}
+
+	if state <= 25 {
+		return string(rune('A' + state - 2)), nil
+	}
+
+	n := state - 26
+	prefixLetters := "pqrstuvwxy"
+	prefixIndex := n / 24
+	letterIndex := n % 24
+
+	if prefixIndex >= len(prefixLetters) {
+		return "", fmt.Errorf("state %d is too large to encode in RLE", state)
+	}
+
+	return string(prefixLetters[prefixIndex]) + string(rune('A'+letterIndex)), nil
+}
+
+func (RLECodec) Encode(w *world.World, meta Metadata, out ioPkg.Writer) error {
+	if meta.Name != "" {
+		fmt.Fprintf(out, "#N %s\n", meta.Name)
+	}
+
+	for _, comment := range meta.Comments {
+		fmt.Fprintf(out, "#C %s\n", comment)
+	}
+
+	if meta.Author != "" {
+		fmt.Fprintf(out, "#O %s\n", meta.Author)
+	}
+
+	bounds, empty := w.LiveBounds()
+
+	width, height := int64(0), int64(0)
+
+	if !empty {
+		width, height = bounds.Size()
+	}
+
+	fmt.Fprintf(out, "x = %d, y = %d, rule = %s\n", width, height, meta.Rule)
+
+	if empty {
+		fmt.Fprintln(out, "!")
+
+		return nil
+	}
+
+	var body strings.Builder
+
+	for y := bounds.MaxY; y >= bounds.MinY; y-- {
+		col := bounds.MinX
+
+		for col <= bounds.MaxX {
+			state := w.Get(col, y)
+			run := int64(1)
+
+			for col+run <= bounds.MaxX && w.Get(col+run, y) == state {
+				run++
+			}
+
+			if run > 1 {
+				fmt.Fprintf(&body, "%d", run)
+			}
+
+			token, err := stateToRLEToken(state)
+
+			if err != nil {
+				return err
+			}
+
+			body.WriteString(token)
+
+			col += run
+		}
+
+		if y > bounds.MinY {
+			body.WriteByte('$')
+		}
+	}
+
+	body.WriteByte('!')
+
+	line := body.String()
+
+	for len(line) > 70 {
+		fmt.Fprintln(out, line[:70])
+		line = line[70:]
+	}
+
+	fmt.Fprintln(out, line)
+
+	return nil
+}
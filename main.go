@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -9,11 +10,15 @@ import (
 	"log"
 	"math"
 	"os"
-	"sort"
+	"runtime"
 	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	lifeio "github.com/rfpludwick/game-of-life/io"
+	"github.com/rfpludwick/game-of-life/sim"
+	"github.com/rfpludwick/game-of-life/world"
 )
 
 // Configuration file struct
@@ -26,6 +31,16 @@ type ConfigurationFile struct {
 	WorldDimensions    ConfigurationFileWorldDimensions `yaml:"world_dimensions"`
 	NewLifeSpawn       []int                            `yaml:"new_life_spawn"`
 	ExistingLifeRemain []int                            `yaml:"existing_life_remain"`
+	Engine             string                           `yaml:"engine"`
+	BigTicks           uint64                           `yaml:"bigticks"`
+	Format             string                           `yaml:"format"`
+	Rule               string                           `yaml:"rule"`
+	Generations        uint64                           `yaml:"generations"`
+	Range              uint64                           `yaml:"range"`
+	Neighborhood       string                           `yaml:"neighborhood"`
+	LTLBirth           string                           `yaml:"ltl_birth"`
+	LTLSurvive         string                           `yaml:"ltl_survive"`
+	Workers            uint64                           `yaml:"workers"`
 }
 
 type ConfigurationFileWorldDimensions struct {
@@ -50,6 +65,16 @@ var (
 	flagWorldDimensions    string
 	flagNewLifeSpawn       string
 	flagExistingLifeRemain string
+	flagEngine             string
+	flagBigTicks           uint64
+	flagFormat             string
+	flagRule               string
+	flagGenerations        uint64
+	flagRange              uint64
+	flagNeighborhood       string
+	flagLTLBirth           string
+	flagLTLSurvive         string
+	flagWorkers            int
 
 	appInputFile          string
 	appOutputFile         string
@@ -62,6 +87,19 @@ var (
 	appWorldMaxY          int64
 	appNewLifeSpawn       []int
 	appExistingLifeRemain []int
+	appEngine             string
+	appBigTicks           uint64
+	appFormat             string
+	appGenerations        uint64
+	appLTLRange           int64
+	appNeighborhood       string
+	appLTLBirth           []world.CountInterval
+	appLTLSurvive         []world.CountInterval
+	appHasLTLBirth        bool
+	appHasLTLSurvive      bool
+	appWorkers            int
+	appRule               world.Rule
+	appPatternMeta        lifeio.Metadata
 
 	ticksDigitsLength uint64
 )
@@ -76,6 +114,12 @@ func init() {
 	appWorldMaxY = math.MaxInt64
 	appNewLifeSpawn = []int{3}
 	appExistingLifeRemain = []int{2, 3}
+	appEngine = "map"
+	appFormat = "life106"
+	appGenerations = 2
+	appLTLRange = 1
+	appNeighborhood = "moore"
+	appWorkers = runtime.NumCPU()
 
 	flag.StringVar(&flagConfigurationFile, "configuration", "", "Path to configuration file to use")
 	flag.BoolVar(&flagShowHelp, "help", false, "Show help")
@@ -87,6 +131,16 @@ func init() {
 	flag.StringVar(&flagWorldDimensions, "world", "", "The dimensions of the world; in format min-x:max-x;min-y:max-y")
 	flag.StringVar(&flagNewLifeSpawn, "newlife", "", "How many neighbors are required for new life to spawn; comma-delimited integer format")
 	flag.StringVar(&flagExistingLifeRemain, "exlife", "", "How many neighbors are required for existing life to remain; comma-delimited integer format")
+	flag.StringVar(&flagEngine, "engine", "", "Simulation engine to use: map (default) or hashlife")
+	flag.Uint64Var(&flagBigTicks, "bigticks", 0, "With -engine=hashlife, advance at least 2^n generations per step instead of 1 (0 disables)")
+	flag.StringVar(&flagFormat, "format", "", "Output pattern format to use: life106 (default), life105, or rle")
+	flag.StringVar(&flagRule, "rule", "", "Rule string in B/S notation (e.g. B3/S23) or Generations notation (e.g. B3/S23/C3); -newlife/-exlife override its birth/survival parts")
+	flag.Uint64Var(&flagGenerations, "generations", 0, "Number of states C for a Generations rule (0 keeps the standard two-state behavior unless a rule string sets it)")
+	flag.Uint64Var(&flagRange, "range", 0, "Neighborhood range R for a Larger-than-Life rule (0 keeps the standard range-1 behavior)")
+	flag.StringVar(&flagNeighborhood, "neighborhood", "", "Neighborhood shape to use: moore (default) or vonneumann")
+	flag.StringVar(&flagLTLBirth, "ltlbirth", "", "Larger-than-Life birth neighbor-count intervals; comma-delimited, e.g. 6-9,12")
+	flag.StringVar(&flagLTLSurvive, "ltlsurvive", "", "Larger-than-Life survival neighbor-count intervals; comma-delimited, e.g. 5-7")
+	flag.IntVar(&flagWorkers, "workers", 0, "Number of concurrent workers to shard each tick across (0 uses runtime.NumCPU(); 1 disables sharding)")
 }
 
 func main() {
@@ -96,103 +150,131 @@ func main() {
 	processConfigurationCli()
 	bootstrap()
 
-	organisms := seedLife()
+	w, meta := seedLife()
 
-	// Run the simulation
-	var tick uint64
+	// Rebuild appRule now that seeding has parsed any rule string embedded in
+	// the pattern file itself (decoding takes no rule and stores cells
+	// without stubbing their neighbors), then stub out every live cell's
+	// neighbors under the now-finalized rule
+	appRule = buildRule()
+	w.StubNeighbors(appRule)
 
-	for tick = 0; tick < appTicks; tick++ {
-		outputOrganismsTick(organisms, tick)
+	appPatternMeta = meta
+	appPatternMeta.Rule = formatRuleString()
 
-		organismsNext := make(map[int64]map[int64]int)
+	var final *world.World
 
-		for coordX, coordYs := range organisms {
-			coordXLeft, coordXRight, coordXLeftExists, coordXRightExists := getNeighborsX(coordX)
+	standardRule, isStandardRule := appRule.(*world.StandardRule)
 
-			for coordY, alive := range coordYs {
-				coordYBottom, coordYTop, coordYBottomExists, coordYTopExists := getNeighborsY(coordY)
+	if appEngine == "hashlife" && appWraparound {
+		log.Print("Warning: -engine=hashlife does not support a wrapping world; falling back to the sharded engine")
+	} else if appEngine == "hashlife" && !isStandardRule {
+		log.Print("Warning: -engine=hashlife only supports the standard B/S rule; falling back to the sharded engine")
+	}
 
-				// How many neighbors?
-				neighborsAlive := 0
+	if appEngine == "hashlife" && !appWraparound && isStandardRule {
+		final = sim.RunHashlife(w, standardRule, appTicks, appBigTicks, emitTick)
+	} else {
+		simulator := sim.New(w, appRule, appWorkers)
 
-				if coordXLeftExists {
-					neighborsAlive += hasLife(organisms, coordXLeft, coordY)
+		if err := simulator.Run(context.Background(), appTicks, emitTick); err != nil {
+			log.Fatal("Simulation error:", err)
+		}
 
-					if coordYBottomExists {
-						neighborsAlive += hasLife(organisms, coordXLeft, coordYBottom)
-					}
+		final = simulator.World
+	}
 
-					if coordYTopExists {
-						neighborsAlive += hasLife(organisms, coordXLeft, coordYTop)
-					}
-				}
+	// And we're done; let's wrap up
+	var file io.Writer
+	var err error
 
-				if coordXRightExists {
-					neighborsAlive += hasLife(organisms, coordXRight, coordY)
+	if appOutputFile != "" {
+		if file, err = os.OpenFile(appOutputFile, os.O_RDWR|os.O_CREATE, 0755); err != nil {
+			log.Fatal("Error opening output file:", appOutputFile)
+		}
+	} else {
+		file = os.Stdout
+	}
 
-					if coordYBottomExists {
-						neighborsAlive += hasLife(organisms, coordXRight, coordYBottom)
-					}
+	outputOrganisms(final, file)
+	os.Exit(0)
+}
 
-					if coordYTopExists {
-						neighborsAlive += hasLife(organisms, coordXRight, coordYTop)
-					}
-				}
+// Returns the world.Rule described by the current app vars: a
+// GenerationsRule when appGenerations >= 3, a LargerThanLifeRule when a
+// non-default range, neighborhood, or LTL interval was configured, and a
+// StandardRule otherwise
+func buildRule() world.Rule {
+	return world.BuildRule(world.RuleConfig{
+		Birth:         appNewLifeSpawn,
+		Survive:       appExistingLifeRemain,
+		Generations:   int(appGenerations),
+		Range:         appLTLRange,
+		VonNeumann:    appNeighborhood == "vonneumann",
+		HasLTLBirth:   appHasLTLBirth,
+		HasLTLSurvive: appHasLTLSurvive,
+		LTLBirth:      appLTLBirth,
+		LTLSurvive:    appLTLSurvive,
+	})
+}
 
-				if coordYBottomExists {
-					neighborsAlive += hasLife(organisms, coordX, coordYBottom)
-				}
-				if coordYTopExists {
-					neighborsAlive += hasLife(organisms, coordX, coordYTop)
-				}
+// Formats appNewLifeSpawn/appExistingLifeRemain/appGenerations as a
+// "B3/S23" rule string, with a "/C3" suffix appended for Generations rules
+func formatRuleString() string {
+	return world.FormatRuleString(appNewLifeSpawn, appExistingLifeRemain, int(appGenerations))
+}
 
-				// Coordinates will be alive *by default* if:
-				// 1. Already alive and 2-3 live neighbors
-				// 2. Not alive and 3 live neighbors
-				// This can be configured via CLI parameters
-				add := false
+// Parses a rule string in standard B/S notation (e.g. "B3/S23", or
+// "S23/B3"), optionally followed by a Generations state count (e.g.
+// "B3/S23/C3"), into appNewLifeSpawn/appExistingLifeRemain/appGenerations.
+// An explicit -newlife flag always wins over the B part, -exlife over the
+// S part, and
+// -generations always wins over the C part, whether the rule string came
+// from a CLI flag, a configuration file, or a pattern file header.
+func applyRuleString(rule string) {
+	parsed, err := world.ParseRuleString(rule)
 
-				var neighborsCheck *[]int
+	if err != nil {
+		log.Fatal("Input error:", err)
+	}
 
-				if alive == 1 {
-					neighborsCheck = &appExistingLifeRemain
-				} else {
-					neighborsCheck = &appNewLifeSpawn
-				}
+	if flagNewLifeSpawn == "" {
+		appNewLifeSpawn = parsed.Birth
+	}
 
-				for _, neighbors := range *neighborsCheck {
-					if neighborsAlive == neighbors {
-						add = true
+	if flagExistingLifeRemain == "" {
+		appExistingLifeRemain = parsed.Survive
+	}
 
-						break
-					}
-				}
+	if parsed.Generations != 0 && flagGenerations == 0 {
+		appGenerations = uint64(parsed.Generations)
+	}
+}
 
-				if add {
-					addOrganism(organismsNext, coordX, coordY)
-				}
-			}
-		}
+// Parses a comma-delimited list of Larger-than-Life birth neighbor-count
+// intervals (e.g. "6-9,12") into appLTLBirth
+func applyLTLBirth(value string) {
+	intervals, err := world.ParseIntervalList(value)
 
-		organisms = organismsNext
+	if err != nil {
+		log.Fatal("CLI flags error:", err)
 	}
 
-	// And we're done; let's wrap up
-	outputOrganismsTick(organisms, appTicks)
+	appLTLBirth = intervals
+	appHasLTLBirth = true
+}
 
-	var file io.Writer
-	var err error
+// Parses a comma-delimited list of Larger-than-Life survival
+// neighbor-count intervals (e.g. "5-7") into appLTLSurvive
+func applyLTLSurvive(value string) {
+	intervals, err := world.ParseIntervalList(value)
 
-	if appOutputFile != "" {
-		if file, err = os.OpenFile(appOutputFile, os.O_RDWR|os.O_CREATE, 0755); err != nil {
-			log.Fatal("Error opening output file:", appOutputFile)
-		}
-	} else {
-		file = os.Stdout
+	if err != nil {
+		log.Fatal("CLI flags error:", err)
 	}
 
-	outputOrganisms(organisms, file)
-	os.Exit(0)
+	appLTLSurvive = intervals
+	appHasLTLSurvive = true
 }
 
 // Parses CLI flags
@@ -257,6 +339,46 @@ func processConfigurationFile() {
 		if len(cf.ExistingLifeRemain) > 0 {
 			appExistingLifeRemain = cf.ExistingLifeRemain
 		}
+
+		if cf.Engine != "" {
+			appEngine = cf.Engine
+		}
+
+		if cf.BigTicks != 0 {
+			appBigTicks = cf.BigTicks
+		}
+
+		if cf.Format != "" {
+			appFormat = cf.Format
+		}
+
+		if cf.Rule != "" {
+			applyRuleString(cf.Rule)
+		}
+
+		if cf.Generations != 0 {
+			appGenerations = cf.Generations
+		}
+
+		if cf.Range != 0 {
+			appLTLRange = int64(cf.Range)
+		}
+
+		if cf.Neighborhood != "" {
+			appNeighborhood = cf.Neighborhood
+		}
+
+		if cf.LTLBirth != "" {
+			applyLTLBirth(cf.LTLBirth)
+		}
+
+		if cf.LTLSurvive != "" {
+			applyLTLSurvive(cf.LTLSurvive)
+		}
+
+		if cf.Workers != 0 {
+			appWorkers = int(cf.Workers)
+		}
 	}
 }
 
@@ -278,7 +400,7 @@ func processConfigurationCli() {
 		appTicks = flagTicks
 	}
 
-	if !flagDisableWraparound {
+	if flagDisableWraparound {
 		appWraparound = false
 	}
 
@@ -332,39 +454,63 @@ func processConfigurationCli() {
 	}
 
 	if flagNewLifeSpawn != "" {
-		appNewLifeSpawn = []int{}
+		neighbors, err := world.ParseNeighborList(flagNewLifeSpawn)
 
-		for _, neighborString := range strings.Split(flagNewLifeSpawn, ",") {
-			neighbor, err := strconv.Atoi(strings.TrimSpace(neighborString))
+		if err != nil {
+			log.Fatal("CLI flags error:", err)
+		}
 
-			if err != nil {
-				log.Fatalf("CLI flags error: Unable to parse integer from newLifeSpawn string %s: %s", neighborString, err)
-			}
+		appNewLifeSpawn = neighbors
+	}
 
-			if neighbor < 1 {
-				log.Fatalf("CLI flags error: Neighbor integer %d must be greater than 0", neighbor)
-			}
+	if flagExistingLifeRemain != "" {
+		neighbors, err := world.ParseNeighborList(flagExistingLifeRemain)
 
-			appNewLifeSpawn = append(appNewLifeSpawn, neighbor)
+		if err != nil {
+			log.Fatal("CLI flags error:", err)
 		}
+
+		appExistingLifeRemain = neighbors
 	}
 
-	if flagExistingLifeRemain != "" {
-		appExistingLifeRemain = []int{}
+	if flagEngine != "" {
+		appEngine = flagEngine
+	}
 
-		for _, neighborString := range strings.Split(flagExistingLifeRemain, ",") {
-			neighbor, err := strconv.Atoi(strings.TrimSpace(neighborString))
+	if flagBigTicks != 0 {
+		appBigTicks = flagBigTicks
+	}
 
-			if err != nil {
-				log.Fatalf("CLI flags error: Unable to parse integer from existingLifeRemain string %s: %s", neighborString, err)
-			}
+	if flagFormat != "" {
+		appFormat = flagFormat
+	}
 
-			if neighbor < 1 {
-				log.Fatalf("CLI flags error: Neighbor integer %d must be greater than 0", neighbor)
-			}
+	if flagRule != "" {
+		applyRuleString(flagRule)
+	}
 
-			appExistingLifeRemain = append(appExistingLifeRemain, neighbor)
-		}
+	if flagGenerations != 0 {
+		appGenerations = flagGenerations
+	}
+
+	if flagRange != 0 {
+		appLTLRange = int64(flagRange)
+	}
+
+	if flagNeighborhood != "" {
+		appNeighborhood = flagNeighborhood
+	}
+
+	if flagLTLBirth != "" {
+		applyLTLBirth(flagLTLBirth)
+	}
+
+	if flagLTLSurvive != "" {
+		applyLTLSurvive(flagLTLSurvive)
+	}
+
+	if flagWorkers != 0 {
+		appWorkers = flagWorkers
 	}
 }
 
@@ -398,6 +544,31 @@ func bootstrap() {
 		}
 	}
 
+	// Engine sanity check
+	if appEngine != "map" && appEngine != "hashlife" {
+		log.Fatalf("Bootstrap error: Unknown engine %s; expected map or hashlife", appEngine)
+	}
+
+	// Output format sanity check
+	if appFormat != "life106" && appFormat != "life105" && appFormat != "rle" {
+		log.Fatalf("Bootstrap error: Unknown format %s; expected life106, life105, or rle", appFormat)
+	}
+
+	// Neighborhood sanity check
+	if appNeighborhood != "moore" && appNeighborhood != "vonneumann" {
+		log.Fatalf("Bootstrap error: Unknown neighborhood %s; expected moore or vonneumann", appNeighborhood)
+	}
+
+	if appGenerations != 2 && appGenerations < 3 {
+		log.Fatalf("Bootstrap error: Generations state count %d must be at least 3", appGenerations)
+	}
+
+	if appWorkers < 1 {
+		log.Fatal("Bootstrap error: Number of workers must be at least 1:", appWorkers)
+	}
+
+	appRule = buildRule()
+
 	// World dimensions sanity check
 	if appWorldMinX >= appWorldMaxX {
 		log.Fatalf("Bootstrap error: World X dimension minimum %d must be less than world X dimension maximum %d", appWorldMinX, appWorldMaxX)
@@ -408,10 +579,15 @@ func bootstrap() {
 	}
 }
 
-// Seeds the initial set of organisms from the input
-func seedLife() map[int64]map[int64]int {
-	organisms := make(map[int64]map[int64]int)
+// Returns the world bounds described by the current app vars
+func worldBounds() world.Bounds {
+	return world.Bounds{MinX: appWorldMinX, MaxX: appWorldMaxX, MinY: appWorldMinY, MaxY: appWorldMaxY}
+}
 
+// Seeds the initial World from the input, auto-detecting its pattern
+// format, and returns any metadata (name/author/comments/rule string) it
+// carried
+func seedLife() (*world.World, lifeio.Metadata) {
 	var file io.Reader
 	var err error
 
@@ -425,237 +601,55 @@ func seedLife() map[int64]map[int64]int {
 
 	scanner := bufio.NewScanner(file)
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		lineLength := (len(line) - 1)
-
-		// Left parens check
-		if line[0] != 40 {
-			log.Fatal("Input error: Error reading left parenthesis:", line[0])
-		}
+	var lines []string
 
-		// Right parens check
-		if line[lineLength] != 41 {
-			log.Fatal("Input error: Error reading right parenthesis:", line[lineLength])
-		}
-
-		// Parse coordinates
-		coordinates := strings.Split(line[1:lineLength], ",")
-
-		coordX, err := strconv.ParseInt(strings.TrimSpace(coordinates[0]), 10, 64)
-
-		if err != nil {
-			log.Fatalf("Input error: Unable to parse X-coordinate integer from input string %s: %s", coordinates[0], err)
-		}
-
-		coordY, err := strconv.ParseInt(strings.TrimSpace(coordinates[1]), 10, 64)
-
-		if err != nil {
-			log.Fatalf("Input error: Unable to parse Y-coordinate integer from input string %s: %s", coordinates[1], err)
-		}
-
-		// Check within world boundaries
-		if coordX < appWorldMinX {
-			log.Fatalf("Input error: X-coordinate %d outside the world minimum bounds %d", coordX, appWorldMinX)
-		}
-
-		if coordX > appWorldMaxX {
-			log.Fatalf("Input error: X-coordinate %d outside the world maximum bounds %d", coordX, appWorldMaxX)
-		}
-
-		if coordY < appWorldMinY {
-			log.Fatalf("Input error: Y-coordinate %d outside the world minimum bounds %d", coordY, appWorldMinY)
-		}
-
-		if coordY > appWorldMaxY {
-			log.Fatalf("Input error: Y-coordinate %d outside the world maximum bounds %d", coordY, appWorldMaxY)
-		}
-
-		// Add to organisms
-		addOrganism(organisms, coordX, coordY)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
 	}
 
 	if err := scanner.Err(); err != nil {
 		log.Fatal("Input error: Error reading standard input:", err)
 	}
 
-	return organisms
-}
-
-// Returns the neighbors to the supplied X-coordinate: left and right
-// Also returns booleans marking if the left and right neighbors exist if
-// wraparound is enabled
-func getNeighborsX(coordX int64) (int64, int64, bool, bool) {
-	var coordXLeft int64
-	coordXLeftExists := true
-	var coordXRight int64
-	coordXRightExists := true
-
-	if coordX == appWorldMinX {
-		if appWraparound {
-			coordXLeft = appWorldMaxX
-		} else {
-			coordXLeftExists = false
-		}
-	} else {
-		coordXLeft = coordX - 1
+	if len(lines) == 0 {
+		return world.New(worldBounds(), appWraparound), lifeio.Metadata{}
 	}
 
-	if coordX == appWorldMaxX {
-		if appWraparound {
-			coordXRight = appWorldMinX
-		} else {
-			coordXRightExists = false
-		}
-	} else {
-		coordXRight = coordX + 1
-	}
+	codec := lifeio.Detect(lines)
 
-	return coordXLeft, coordXRight, coordXLeftExists, coordXRightExists
-}
+	w, meta, err := codec.Decode(lines, worldBounds(), appWraparound)
 
-// Returns the neighbors to the supplied Y-coordinate: bottom and top
-// Also returns booleans marking if the bottom and top neighbors exist if
-// wraparound is enabled
-func getNeighborsY(coordY int64) (int64, int64, bool, bool) {
-	var coordYBottom int64
-	coordYBottomExists := true
-	var coordYTop int64
-	coordYTopExists := true
-
-	if coordY == appWorldMinY {
-		if appWraparound {
-			coordYBottom = appWorldMaxY
-		} else {
-			coordYBottomExists = false
-		}
-	} else {
-		coordYBottom = coordY - 1
+	if err != nil {
+		log.Fatal("Input error:", err)
 	}
 
-	if coordY == appWorldMaxY {
-		if appWraparound {
-			coordYTop = appWorldMinY
-		} else {
-			coordYTopExists = false
-		}
-	} else {
-		coordYTop = coordY + 1
+	if meta.Rule != "" {
+		applyRuleString(meta.Rule)
 	}
 
-	return coordYBottom, coordYTop, coordYBottomExists, coordYTopExists
+	return w, meta
 }
 
-// Adds an organism at the supplied coordinates
-// Also stubs out 0 life in the organisms map in all surrounding neighbor coordinates for ease of traversal later
-func addOrganism(organisms map[int64]map[int64]int, coordX int64, coordY int64) {
-	coordXLeft, coordXRight, coordXLeftExists, coordXRightExists := getNeighborsX(coordX)
-	coordYBottom, coordYTop, coordYBottomExists, coordYTopExists := getNeighborsY(coordY)
-
-	coordXs := []int64{coordX}
-	coordYs := []int64{coordY}
-
-	if coordXLeftExists {
-		coordXs = append(coordXs, coordXLeft)
-	}
-
-	if coordXRightExists {
-		coordXs = append(coordXs, coordXRight)
-	}
-
-	if coordYBottomExists {
-		coordYs = append(coordYs, coordYBottom)
-	}
-
-	if coordYTopExists {
-		coordYs = append(coordYs, coordYTop)
-	}
-
-	for _, tempCoordX := range coordXs {
-		if _, ok := organisms[tempCoordX]; !ok {
-			organisms[tempCoordX] = make(map[int64]int)
-		}
-
-		for _, tempCoordY := range coordYs {
-			if _, ok := organisms[tempCoordX][tempCoordY]; !ok {
-				organisms[tempCoordX][tempCoordY] = 0
-			}
-		}
-	}
-
-	organisms[coordX][coordY] = 1
-}
-
-// Returns 1 for life, 0 for none for the supplied coordinates
-func hasLife(organisms map[int64]map[int64]int, coordX int64, coordY int64) int {
-	if coordYs, ok := organisms[coordX]; ok {
-		if value, ok := coordYs[coordY]; ok {
-			return value
-		}
-	}
-
-	return 0
-}
-
-// Outputs the organisms in Life 1.06 format to the supplied writer; sorted too
-func outputOrganisms(organisms map[int64]map[int64]int, file io.Writer) {
-	coordXs := getSortedCoordXs(organisms)
-
-	fmt.Fprintln(file, "#Life 1.06")
-
-	for _, coordX := range coordXs {
-		coordYs := getSortedCoordYs(organisms[coordX])
-
-		for _, coordY := range coordYs {
-			if organisms[coordX][coordY] == 1 {
-				fmt.Fprintf(file, "%d %d\n", coordX, coordY)
-			}
-		}
+// Outputs the world in the configured pattern format to the supplied
+// writer (-format; defaults to Life 1.06)
+func outputOrganisms(w *world.World, file io.Writer) {
+	if err := lifeio.ByName(appFormat).Encode(w, appPatternMeta, file); err != nil {
+		log.Fatal("Output error:", err)
 	}
 }
 
-// Outputs the organisms for a particular tick
-func outputOrganismsTick(organisms map[int64]map[int64]int, tick uint64) {
+// Outputs the world for a particular tick, if -outdir was given; matches
+// the emit signature expected by sim.Simulator.Run and sim.RunHashlife
+func emitTick(tick uint64, w *world.World) {
 	if appOutputDirectory != "" {
-		var file io.Writer
-		var err error
-
 		filename := fmt.Sprintf("%s/%0"+strconv.Itoa(int(ticksDigitsLength))+"d.txt", appOutputDirectory, tick)
 
-		if file, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0755); err != nil {
+		file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0755)
+
+		if err != nil {
 			log.Fatalf("Error opening output file %s: %s", filename, err)
 		}
 
-		outputOrganisms(organisms, file)
-	}
-}
-
-// Returns the sorted x-coordinate indices
-func getSortedCoordXs(organisms map[int64]map[int64]int) []int64 {
-	keys := make([]int64, 0, len(organisms))
-
-	for k := range organisms {
-		keys = append(keys, k)
+		outputOrganisms(w, file)
 	}
-
-	sort.Slice(keys, func(left, right int) bool {
-		return keys[left] < keys[right]
-	})
-
-	return keys
-}
-
-// Returns the sorted y-coordinate indices
-func getSortedCoordYs(organismsByX map[int64]int) []int64 {
-	keys := make([]int64, 0, len(organismsByX))
-
-	for k := range organismsByX {
-		keys = append(keys, k)
-	}
-
-	sort.Slice(keys, func(left, right int) bool {
-		return keys[left] < keys[right]
-	})
-
-	return keys
 }
@@ -0,0 +1,537 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// Hashlife engine: represents the world as a canonicalized quadtree so that
+// structurally identical subregions share memory, and memoizes each node's
+// advanced "result" so repeated/periodic structures are only ever computed
+// once. Only usable for an infinite, open universe with the classic
+// two-state Moore-neighborhood rule; RunHashlife's caller is responsible
+// for falling back to the serial/sharded stepper otherwise.
+
+// A quadtree node covering a 2^level x 2^level square of the world.
+// Level 0 is a single cell (population 0 or 1); every other level is made
+// up of four children one level smaller. Nodes are hash-consed via
+// hlNodeCache so that two structurally identical subtrees are always the
+// same *hlNode, which is what lets the memoized fields below pay off.
+type hlNode struct {
+	level          int
+	population     int64
+	nw, ne, sw, se *hlNode
+
+	// result is the memoized center of this node, advanced 2^(level-2)
+	// generations; populated lazily by result
+	result *hlNode
+
+	// oneGenResult is the memoized center of this node, advanced exactly
+	// one generation; populated lazily by oneGen. It needs its own cache
+	// separate from result: result's cached value means "advanced
+	// 2^(level-2) generations", which is a different number of
+	// generations at every level, so it can't also answer "advanced by
+	// exactly 1 generation" for an arbitrary node.
+	oneGenResult *hlNode
+}
+
+type hlNodeKey struct {
+	level          int
+	nw, ne, sw, se *hlNode
+}
+
+type hlState struct {
+	nodeCache  map[hlNodeKey]*hlNode
+	emptyNodes map[int]*hlNode
+	dead       *hlNode
+	alive      *hlNode
+}
+
+// newHLState sets up fresh hash-consing caches for a run
+func newHLState() *hlState {
+	return &hlState{
+		nodeCache:  make(map[hlNodeKey]*hlNode),
+		emptyNodes: make(map[int]*hlNode),
+		dead:       &hlNode{level: 0, population: 0},
+		alive:      &hlNode{level: 0, population: 1},
+	}
+}
+
+// Returns the canonical node for the given children, creating and caching
+// one if this combination hasn't been seen before
+func (s *hlState) newNode(nw, ne, sw, se *hlNode) *hlNode {
+	key := hlNodeKey{level: nw.level + 1, nw: nw, ne: ne, sw: sw, se: se}
+
+	if node, ok := s.nodeCache[key]; ok {
+		return node
+	}
+
+	node := &hlNode{
+		level:      key.level,
+		population: nw.population + ne.population + sw.population + se.population,
+		nw:         nw,
+		ne:         ne,
+		sw:         sw,
+		se:         se,
+	}
+
+	s.nodeCache[key] = node
+
+	return node
+}
+
+// Returns the canonical empty node at the given level
+func (s *hlState) emptyNode(level int) *hlNode {
+	if level == 0 {
+		return s.dead
+	}
+
+	if node, ok := s.emptyNodes[level]; ok {
+		return node
+	}
+
+	child := s.emptyNode(level - 1)
+	node := s.newNode(child, child, child, child)
+	s.emptyNodes[level] = node
+
+	return node
+}
+
+// Sets the cell at (x, y) to alive within node (which covers
+// [originX, originX+2^level) x [originY, originY+2^level)), returning the
+// canonical node for the result
+func (s *hlState) setCell(node *hlNode, originX int64, originY int64, x int64, y int64) *hlNode {
+	if node.level == 0 {
+		return s.alive
+	}
+
+	half := int64(1) << (node.level - 1)
+	midX := originX + half
+	midY := originY + half
+
+	if x < midX {
+		if y < midY {
+			return s.newNode(node.nw, node.ne, s.setCell(node.sw, originX, originY, x, y), node.se)
+		}
+
+		return s.newNode(s.setCell(node.nw, originX, midY, x, y), node.ne, node.sw, node.se)
+	}
+
+	if y < midY {
+		return s.newNode(node.nw, node.ne, node.sw, s.setCell(node.se, midX, originY, x, y))
+	}
+
+	return s.newNode(node.nw, s.setCell(node.ne, midX, midY, x, y), node.sw, node.se)
+}
+
+// Walks every live cell in node and invokes visit with its absolute
+// coordinates
+func hlCollect(node *hlNode, originX int64, originY int64, visit func(x int64, y int64)) {
+	if node.population == 0 {
+		return
+	}
+
+	if node.level == 0 {
+		visit(originX, originY)
+
+		return
+	}
+
+	half := int64(1) << (node.level - 1)
+
+	hlCollect(node.nw, originX, originY+half, visit)
+	hlCollect(node.ne, originX+half, originY+half, visit)
+	hlCollect(node.sw, originX, originY, visit)
+	hlCollect(node.se, originX+half, originY, visit)
+}
+
+// Doubles the universe around node, keeping it centered, so there's room
+// for life to spread into before the next step
+func (s *hlState) expand(node *hlNode, originX int64, originY int64) (*hlNode, int64, int64) {
+	empty := s.emptyNode(node.level - 1)
+	half := int64(1) << (node.level - 1)
+
+	newNW := s.newNode(empty, empty, empty, node.nw)
+	newNE := s.newNode(empty, empty, node.ne, empty)
+	newSW := s.newNode(empty, node.sw, empty, empty)
+	newSE := s.newNode(node.se, empty, empty, empty)
+
+	return s.newNode(newNW, newNE, newSW, newSE), originX - half, originY - half
+}
+
+// Returns the bounding box of every live cell in node (which covers
+// [originX, originX+2^level) x [originY, originY+2^level)), or empty=true
+// if node has no live cells. Cheap relative to a full World conversion:
+// it's a bare pointer walk that skips empty subtrees by population, with
+// no map allocation or neighbor stubbing.
+func hlLiveBounds(node *hlNode, originX int64, originY int64) (minX int64, maxX int64, minY int64, maxY int64, empty bool) {
+	if node.population == 0 {
+		return 0, 0, 0, 0, true
+	}
+
+	if node.level == 0 {
+		return originX, originX, originY, originY, false
+	}
+
+	half := int64(1) << (node.level - 1)
+
+	minX, minY = math.MaxInt64, math.MaxInt64
+	maxX, maxY = math.MinInt64, math.MinInt64
+	empty = true
+
+	merge := func(childMinX int64, childMaxX int64, childMinY int64, childMaxY int64, childEmpty bool) {
+		if childEmpty {
+			return
+		}
+
+		empty = false
+
+		if childMinX < minX {
+			minX = childMinX
+		}
+		if childMaxX > maxX {
+			maxX = childMaxX
+		}
+		if childMinY < minY {
+			minY = childMinY
+		}
+		if childMaxY > maxY {
+			maxY = childMaxY
+		}
+	}
+
+	merge(hlLiveBounds(node.nw, originX, originY+half))
+	merge(hlLiveBounds(node.ne, originX+half, originY+half))
+	merge(hlLiveBounds(node.sw, originX, originY))
+	merge(hlLiveBounds(node.se, originX+half, originY))
+
+	return
+}
+
+// Expands root until its live cells sit at least margin(level) from every
+// edge, re-measuring after each expansion. oneGen/result tile a node's
+// center with nine overlapping half-size subnodes and recurse on those,
+// so any call needs the classic "inner half" containment (margin >=
+// size/4, so the recursive halving never reads outside root). A call that
+// goes on to advance the pattern by g generations needs an extra
+// ceil(g/4) of slack on top of that, to cover how far the pattern can
+// spread toward the edge while those g generations are computed.
+func (s *hlState) ensureMargin(root *hlNode, originX int64, originY int64, margin func(level int) int64) (*hlNode, int64, int64) {
+	for {
+		minX, maxX, minY, maxY, empty := hlLiveBounds(root, originX, originY)
+
+		if empty {
+			return root, originX, originY
+		}
+
+		size := int64(1) << root.level
+		need := margin(root.level)
+
+		if minX-originX >= need && (originX+size-1)-maxX >= need &&
+			minY-originY >= need && (originY+size-1)-maxY >= need {
+			return root, originX, originY
+		}
+
+		root, originX, originY = s.expand(root, originX, originY)
+	}
+}
+
+// marginForGenerations returns the margin a call advancing a fixed number
+// of generations needs from every edge: the structural size/4 containment
+// plus ceil(generations/4) of slack for drift during the advance
+func marginForGenerations(generations int64) func(level int) int64 {
+	return func(level int) int64 {
+		size := int64(1) << level
+
+		return size/4 + (generations+3)/4
+	}
+}
+
+// marginForResult is the margin a result() call on a node of the given
+// level needs: result() advances a level-n node's center by exactly
+// 2^(n-2) generations, so its generation count is derived from the node's
+// own level rather than being a fixed input
+func marginForResult(level int) int64 {
+	return marginForGenerations(int64(1) << (level - 2))(level)
+}
+
+// Builds the initial quadtree covering every live cell in w, with generous
+// empty padding so the simulation has room to run before a re-expansion is
+// needed
+func (s *hlState) buildFromWorld(w *world.World) (*hlNode, int64, int64) {
+	bounds, empty := w.LiveBounds()
+
+	if empty {
+		return s.emptyNode(2), 0, 0
+	}
+
+	span := bounds.MaxX - bounds.MinX + 1
+	if ySpan := bounds.MaxY - bounds.MinY + 1; ySpan > span {
+		span = ySpan
+	}
+
+	level := 2
+	for int64(1)<<level < span {
+		level++
+	}
+
+	// Pad generously beyond the minimal bounding square; empty regions are
+	// free thanks to hash-consed canonical empty nodes
+	level += 2
+
+	size := int64(1) << level
+	originX := bounds.MinX - (size-span)/2
+	originY := bounds.MinY - (size-span)/2
+
+	root := s.emptyNode(level)
+
+	w.Each(func(x, y int64, state int) {
+		if state == 1 {
+			root = s.setCell(root, originX, originY, x, y)
+		}
+	})
+
+	return root, originX, originY
+}
+
+// Converts a quadtree back into a World, stubbing out neighbors exactly as
+// World.Set does
+func hlToWorld(node *hlNode, originX int64, originY int64, bounds world.Bounds, wraparound bool, rule world.Rule) *world.World {
+	w := world.New(bounds, wraparound)
+
+	hlCollect(node, originX, originY, func(x int64, y int64) {
+		w.Set(x, y, 1, rule)
+	})
+
+	return w
+}
+
+// Applies the brute-force rules to the 4x4 cells of a level-2 node,
+// returning the level-1 center advanced one generation
+func (s *hlState) baseResult(node *hlNode, rule *world.StandardRule) *hlNode {
+	var grid [4][4]int64
+
+	grid[0][0], grid[0][1] = node.nw.nw.population, node.nw.ne.population
+	grid[0][2], grid[0][3] = node.ne.nw.population, node.ne.ne.population
+	grid[1][0], grid[1][1] = node.nw.sw.population, node.nw.se.population
+	grid[1][2], grid[1][3] = node.ne.sw.population, node.ne.se.population
+	grid[2][0], grid[2][1] = node.sw.nw.population, node.sw.ne.population
+	grid[2][2], grid[2][3] = node.se.nw.population, node.se.ne.population
+	grid[3][0], grid[3][1] = node.sw.sw.population, node.sw.se.population
+	grid[3][2], grid[3][3] = node.se.sw.population, node.se.se.population
+
+	nextCell := func(row int, col int) *hlNode {
+		alive := grid[row][col] == 1
+		neighborsAlive := 0
+
+		for deltaRow := -1; deltaRow <= 1; deltaRow++ {
+			for deltaCol := -1; deltaCol <= 1; deltaCol++ {
+				if deltaRow == 0 && deltaCol == 0 {
+					continue
+				}
+
+				r, c := row+deltaRow, col+deltaCol
+
+				if r < 0 || r > 3 || c < 0 || c > 3 {
+					continue
+				}
+
+				if grid[r][c] == 1 {
+					neighborsAlive++
+				}
+			}
+		}
+
+		state := 0
+		if alive {
+			state = 1
+		}
+
+		if rule.NextState(state, neighborsAlive) == 1 {
+			return s.alive
+		}
+
+		return s.dead
+	}
+
+	return s.newNode(nextCell(1, 1), nextCell(1, 2), nextCell(2, 1), nextCell(2, 2))
+}
+
+// Returns the nine overlapping level-(n-1) subnodes used by both result
+// and oneGen to combine a level-n node
+func (s *hlState) subnodes(node *hlNode) (n00, n01, n02, n10, n11, n12, n20, n21, n22 *hlNode) {
+	nw, ne, sw, se := node.nw, node.ne, node.sw, node.se
+
+	n00 = nw
+	n01 = s.newNode(nw.ne, ne.nw, nw.se, ne.sw)
+	n02 = ne
+	n10 = s.newNode(nw.sw, nw.se, sw.nw, sw.ne)
+	n11 = s.newNode(nw.se, ne.sw, sw.ne, se.nw)
+	n12 = s.newNode(ne.sw, ne.se, se.nw, se.ne)
+	n20 = sw
+	n21 = s.newNode(sw.ne, se.nw, sw.se, se.sw)
+	n22 = se
+
+	return
+}
+
+// Returns the center of node advanced 2^(level-2) generations, memoizing
+// the answer in node.result so repeated subtrees are only ever solved once
+func (s *hlState) result(node *hlNode, rule *world.StandardRule) *hlNode {
+	if node.result != nil {
+		return node.result
+	}
+
+	if node.population == 0 {
+		node.result = node.nw
+
+		return node.result
+	}
+
+	var result *hlNode
+
+	if node.level == 2 {
+		result = s.baseResult(node, rule)
+	} else {
+		n00, n01, n02, n10, n11, n12, n20, n21, n22 := s.subnodes(node)
+
+		r00, r01, r02 := s.result(n00, rule), s.result(n01, rule), s.result(n02, rule)
+		r10, r11, r12 := s.result(n10, rule), s.result(n11, rule), s.result(n12, rule)
+		r20, r21, r22 := s.result(n20, rule), s.result(n21, rule), s.result(n22, rule)
+
+		nw2 := s.newNode(r00, r01, r10, r11)
+		ne2 := s.newNode(r01, r02, r11, r12)
+		sw2 := s.newNode(r10, r11, r20, r21)
+		se2 := s.newNode(r11, r12, r21, r22)
+
+		result = s.newNode(s.result(nw2, rule), s.result(ne2, rule), s.result(sw2, rule), s.result(se2, rule))
+	}
+
+	node.result = result
+
+	return result
+}
+
+// Returns the center of node advanced exactly one generation, memoizing
+// the answer in node.oneGenResult (see its doc comment for why this needs
+// a cache of its own, separate from result). Caching here isn't optional:
+// the nine-subnode halving below revisits the same node from multiple
+// overlapping paths, both within a single call and across nodes that
+// recur at different levels, and hash-consing only dedupes node storage,
+// not the recursive call itself — without memoizing the call too, cost
+// would blow up combinatorially (O(9^level) instead of O(level)).
+func (s *hlState) oneGen(node *hlNode, rule *world.StandardRule) *hlNode {
+	if node.oneGenResult != nil {
+		return node.oneGenResult
+	}
+
+	if node.population == 0 {
+		node.oneGenResult = node.nw
+
+		return node.oneGenResult
+	}
+
+	var result *hlNode
+
+	if node.level == 2 {
+		result = s.baseResult(node, rule)
+	} else {
+		n00, n01, n02, n10, n11, n12, n20, n21, n22 := s.subnodes(node)
+
+		a00, a01, a02 := s.oneGen(n00, rule), s.oneGen(n01, rule), s.oneGen(n02, rule)
+		a10, a11, a12 := s.oneGen(n10, rule), s.oneGen(n11, rule), s.oneGen(n12, rule)
+		a20, a21, a22 := s.oneGen(n20, rule), s.oneGen(n21, rule), s.oneGen(n22, rule)
+
+		result = s.newNode(
+			s.newNode(a00.se, a01.sw, a10.ne, a11.nw),
+			s.newNode(a01.se, a02.sw, a11.ne, a12.nw),
+			s.newNode(a10.se, a11.sw, a20.ne, a21.nw),
+			s.newNode(a11.se, a12.sw, a21.ne, a22.nw),
+		)
+	}
+
+	node.oneGenResult = result
+
+	return result
+}
+
+// RunHashlife runs the simulation using the Hashlife quadtree engine,
+// which only understands the classic two-state Moore-neighborhood rule and
+// an infinite, open (non-wrapping) universe. With bigTicks set to n > 0,
+// generations are advanced in chunks via the memoized result, so long
+// runs cost roughly one recursive call per chunk instead of one per
+// generation; a chunk is 2^(level-2) generations for the root once it has
+// been expanded to at least level n+2 and to the margin result needs, so
+// it is at least 2^n and can be larger. When a chunk would overshoot
+// ticks, that tick is advanced by one generation instead. emit is only
+// invoked at chunk boundaries in that mode.
+// Without bigTicks, each tick is advanced one generation at a time.
+func RunHashlife(w *world.World, rule *world.StandardRule, ticks uint64, bigTicks uint64, emit func(tick uint64, w *world.World)) *world.World {
+	s := newHLState()
+
+	root, originX, originY := s.buildFromWorld(w)
+
+	var tick uint64
+
+	for tick < ticks {
+		if emit != nil {
+			emit(tick, hlToWorld(root, originX, originY, w.Bounds, w.Wraparound, rule))
+		}
+
+		tookBigChunk := false
+
+		if bigTicks > 0 {
+			targetLevel := int(bigTicks) + 2
+
+			// Figure out, without touching the real root yet, what level
+			// the margin requirement would actually force it to once a
+			// chunk is taken: ensureMargin may need to expand root past
+			// targetLevel to keep the live pattern inside its inner half,
+			// which makes the chunk result() ends up advancing larger than
+			// the nominal 2^bigTicks. Only commit to the big-chunk branch
+			// if that real chunk still fits within the remaining ticks;
+			// otherwise fall through to oneGen so the run doesn't overshoot
+			// the requested tick count.
+			candidate, candidateOriginX, candidateOriginY := root, originX, originY
+
+			for candidate.level < targetLevel {
+				candidate, candidateOriginX, candidateOriginY = s.expand(candidate, candidateOriginX, candidateOriginY)
+			}
+
+			candidate, candidateOriginX, candidateOriginY = s.ensureMargin(candidate, candidateOriginX, candidateOriginY, marginForResult)
+
+			bigChunk := uint64(1) << (candidate.level - 2)
+
+			if tick+bigChunk <= ticks {
+				shift := int64(1) << (candidate.level - 2)
+				root = s.result(candidate, rule)
+				originX, originY = candidateOriginX+shift, candidateOriginY+shift
+				tick += bigChunk
+				tookBigChunk = true
+			}
+		}
+
+		if !tookBigChunk {
+			// oneGen needs a level-2 node at minimum
+			for root.level < 4 {
+				root, originX, originY = s.expand(root, originX, originY)
+			}
+
+			root, originX, originY = s.ensureMargin(root, originX, originY, marginForGenerations(1))
+
+			shift := int64(1) << (root.level - 2)
+			root = s.oneGen(root, rule)
+			originX, originY = originX+shift, originY+shift
+			tick++
+		}
+	}
+
+	result := hlToWorld(root, originX, originY, w.Bounds, w.Wraparound, rule)
+
+	if emit != nil {
+		emit(tick, result)
+	}
+
+	return result
+}
@@ -0,0 +1,118 @@
+package sim
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// cellResult is one cell's computed next state, as produced by a shard
+// worker for the owner goroutine to merge
+type cellResult struct {
+	x, y  int64
+	state int
+}
+
+// stepSharded computes the next generation of w under rule by partitioning
+// every stubbed coordinate into `workers` horizontal (by Y) strips of the
+// live region, computing each strip concurrently against the read-only
+// snapshot w, and merging the results on the calling (owner) goroutine.
+func stepSharded(ctx context.Context, w *world.World, rule world.Rule, workers int) (*world.World, error) {
+	next := world.New(w.Bounds, w.Wraparound)
+
+	bounds, empty := w.LiveBounds()
+
+	if empty {
+		return next, nil
+	}
+
+	height := bounds.MaxY - bounds.MinY + 1
+
+	if int64(workers) > height {
+		workers = int(height)
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	shards := make([][]world.Coord, workers)
+
+	w.Each(func(x, y int64, state int) {
+		shard := int(((y - bounds.MinY) * int64(workers)) / height)
+
+		if shard >= workers {
+			shard = workers - 1
+		}
+		if shard < 0 {
+			shard = 0
+		}
+
+		shards[shard] = append(shards[shard], world.Coord{X: x, Y: y})
+	})
+
+	offsets := rule.Neighbors()
+	results := make([][]cellResult, workers)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i] = stepShard(w, rule, offsets, shards[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, shardResults := range results {
+		for _, result := range shardResults {
+			next.Set(result.x, result.y, result.state, rule)
+		}
+	}
+
+	return next, nil
+}
+
+// stepShard computes the next state of every coordinate in coords against
+// the read-only snapshot w, returning only the ones that end up not dead
+func stepShard(w *world.World, rule world.Rule, offsets []world.Coord, coords []world.Coord) []cellResult {
+	results := make([]cellResult, 0, len(coords))
+
+	for _, coord := range coords {
+		state := w.Get(coord.X, coord.Y)
+		neighborsAlive := 0
+
+		for _, offset := range offsets {
+			neighborX, xExists := w.Neighbor(coord.X, offset.X, true)
+
+			if !xExists {
+				continue
+			}
+
+			neighborY, yExists := w.Neighbor(coord.Y, offset.Y, false)
+
+			if !yExists {
+				continue
+			}
+
+			if w.Get(neighborX, neighborY) == 1 {
+				neighborsAlive++
+			}
+		}
+
+		if nextState := rule.NextState(state, neighborsAlive); nextState != 0 {
+			results = append(results, cellResult{x: coord.X, y: coord.Y, state: nextState})
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,113 @@
+// Package sim drives a world.World forward under a world.Rule, either on
+// a single goroutine or sharded across a worker pool.
+package sim
+
+import (
+	"context"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+// Simulator advances a World one generation at a time under Rule. When
+// Workers is greater than 1, each Step partitions the live region into
+// Workers horizontal strips and computes them concurrently; otherwise it
+// runs serially on the calling goroutine.
+type Simulator struct {
+	World   *world.World
+	Rule    world.Rule
+	Workers int
+}
+
+// New returns a Simulator for w under rule, using workers goroutines per
+// step (workers <= 1 means serial)
+func New(w *world.World, rule world.Rule, workers int) *Simulator {
+	return &Simulator{World: w, Rule: rule, Workers: workers}
+}
+
+// Step advances the simulation by exactly one generation
+func (s *Simulator) Step(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var next *world.World
+
+	if s.Workers > 1 {
+		var err error
+
+		next, err = stepSharded(ctx, s.World, s.Rule, s.Workers)
+
+		if err != nil {
+			return err
+		}
+	} else {
+		next = stepSerial(s.World, s.Rule)
+	}
+
+	s.World = next
+
+	return nil
+}
+
+// Run advances the simulation ticks generations, invoking emit with the
+// tick number and the resulting World after every step (including tick 0,
+// before anything has been stepped)
+func (s *Simulator) Run(ctx context.Context, ticks uint64, emit func(tick uint64, w *world.World)) error {
+	var tick uint64
+
+	for tick = 0; tick < ticks; tick++ {
+		if emit != nil {
+			emit(tick, s.World)
+		}
+
+		if err := s.Step(ctx); err != nil {
+			return err
+		}
+	}
+
+	if emit != nil {
+		emit(tick, s.World)
+	}
+
+	return nil
+}
+
+// stepSerial computes the next generation of w under rule on the calling
+// goroutine, tracking every stubbed coordinate
+func stepSerial(w *world.World, rule world.Rule) *world.World {
+	offsets := rule.Neighbors()
+	next := world.New(w.Bounds, w.Wraparound)
+
+	w.Each(func(x, y int64, state int) {
+		applyCell(w, next, rule, offsets, x, y, state)
+	})
+
+	return next
+}
+
+// applyCell computes (x, y)'s next state and, if not dead, writes it into next
+func applyCell(w *world.World, next *world.World, rule world.Rule, offsets []world.Coord, x int64, y int64, state int) {
+	neighborsAlive := 0
+
+	for _, offset := range offsets {
+		neighborX, xExists := w.Neighbor(x, offset.X, true)
+
+		if !xExists {
+			continue
+		}
+
+		neighborY, yExists := w.Neighbor(y, offset.Y, false)
+
+		if !yExists {
+			continue
+		}
+
+		if w.Get(neighborX, neighborY) == 1 {
+			neighborsAlive++
+		}
+	}
+
+	if nextState := rule.NextState(state, neighborsAlive); nextState != 0 {
+		next.Set(x, y, nextState, rule)
+	}
+}
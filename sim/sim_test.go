@@ -0,0 +1,166 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/rfpludwick/game-of-life/world"
+)
+
+func seedBenchWorld(size int64) *world.World {
+	bounds := world.Bounds{MinX: -size, MaxX: size, MinY: -size, MaxY: size}
+	w := world.New(bounds, true)
+	rule := &world.StandardRule{Birth: []int{3}, Survive: []int{2, 3}}
+
+	for x := int64(-size / 2); x < size/2; x++ {
+		for y := int64(-size / 2); y < size/2; y++ {
+			if (x+y)%3 == 0 {
+				w.Set(x, y, 1, rule)
+			}
+		}
+	}
+
+	return w
+}
+
+func benchmarkStep(b *testing.B, workers int) {
+	rule := &world.StandardRule{Birth: []int{3}, Survive: []int{2, 3}}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		w := seedBenchWorld(40)
+		s := New(w, rule, workers)
+		b.StartTimer()
+
+		if err := s.Step(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStepSerial(b *testing.B) {
+	benchmarkStep(b, 1)
+}
+
+func BenchmarkStepSharded4(b *testing.B) {
+	benchmarkStep(b, 4)
+}
+
+func BenchmarkStepSharded8(b *testing.B) {
+	benchmarkStep(b, 8)
+}
+
+// gliderWorld returns a World seeded with a single glider at the origin;
+// callers pass bounds wide enough that its drift over many ticks stays
+// well inside them
+func gliderWorld(bounds world.Bounds) *world.World {
+	w := world.New(bounds, false)
+	rule := &world.StandardRule{Birth: []int{3}, Survive: []int{2, 3}}
+
+	for _, coord := range []world.Coord{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+		w.Set(coord.X, coord.Y, 1, rule)
+	}
+
+	return w
+}
+
+// liveCoords returns every live cell in w as a sorted slice, for
+// order-independent comparison between two Worlds
+func liveCoords(w *world.World) []world.Coord {
+	coords := []world.Coord{}
+
+	w.Each(func(x, y int64, state int) {
+		if state != 0 {
+			coords = append(coords, world.Coord{X: x, Y: y})
+		}
+	})
+
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].X != coords[j].X {
+			return coords[i].X < coords[j].X
+		}
+
+		return coords[i].Y < coords[j].Y
+	})
+
+	return coords
+}
+
+// TestRunHashlifeMatchesSharded steps an identical world forward with both
+// RunHashlife and the sharded Simulator and checks they agree on the
+// live cells at the final tick. This is the equivalence check that would have caught
+// both the margin and the quadtree-rebuild-performance regressions fixed
+// alongside it: any divergence between the two engines shows up as a
+// mismatch here instead of only under a timing benchmark.
+//
+// ticks is large enough to force the drifting glider through several
+// quadtree re-centerings (root level growing well past the ~7-8 where the
+// unmemoized-oneGen blowup used to bite); a short run wouldn't exercise
+// that growth at all.
+func TestRunHashlifeMatchesSharded(t *testing.T) {
+	bounds := world.Bounds{MinX: -2000, MaxX: 2000, MinY: -2000, MaxY: 2000}
+	rule := &world.StandardRule{Birth: []int{3}, Survive: []int{2, 3}}
+
+	for _, bigTicks := range []uint64{0, 1, 2, 3, 5} {
+		bigTicks := bigTicks
+
+		t.Run(fmt.Sprintf("bigticks=%d", bigTicks), func(t *testing.T) {
+			const ticks = 300
+
+			shardedWorld := gliderWorld(bounds)
+			shardedSim := New(shardedWorld, rule, 4)
+
+			var gotSharded, gotHashlife []world.Coord
+
+			if err := shardedSim.Run(context.Background(), ticks, func(tick uint64, w *world.World) {
+				if tick == ticks {
+					gotSharded = liveCoords(w)
+				}
+			}); err != nil {
+				t.Fatalf("sharded Run returned error: %v", err)
+			}
+
+			RunHashlife(gliderWorld(bounds), rule, ticks, bigTicks, func(tick uint64, w *world.World) {
+				if tick == ticks {
+					gotHashlife = liveCoords(w)
+				}
+			})
+
+			if len(gotHashlife) != len(gotSharded) {
+				t.Fatalf("hashlife has %d live cells, sharded has %d", len(gotHashlife), len(gotSharded))
+			}
+
+			for i := range gotSharded {
+				if gotHashlife[i] != gotSharded[i] {
+					t.Errorf("live cell %d = %v, want %v", i, gotHashlife[i], gotSharded[i])
+				}
+			}
+		})
+	}
+}
+
+// TestRunHashlifeSingleGenStaysFast runs the default bigTicks=0 path (one
+// generation at a time via oneGen, never the memoized bigchunk result())
+// for far more ticks than TestRunHashlifeMatchesSharded needs, and asserts
+// it stays fast rather than just that its output is correct. A drifting
+// pattern keeps forcing the quadtree root to a higher level; without
+// memoizing oneGen's own advance (not just result's), that growth made
+// this path cost O(9^level) per tick, so a run like this never finished.
+func TestRunHashlifeSingleGenStaysFast(t *testing.T) {
+	bounds := world.Bounds{MinX: -20000, MaxX: 20000, MinY: -20000, MaxY: 20000}
+	rule := &world.StandardRule{Birth: []int{3}, Survive: []int{2, 3}}
+
+	const ticks = 5000
+	const budget = 5 * time.Second
+
+	start := time.Now()
+
+	RunHashlife(gliderWorld(bounds), rule, ticks, 0, nil)
+
+	if elapsed := time.Since(start); elapsed > budget {
+		t.Fatalf("RunHashlife(bigTicks=0) over %d ticks took %s, want under %s", ticks, elapsed, budget)
+	}
+}
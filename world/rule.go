@@ -0,0 +1,190 @@
+package world
+
+// mooreOffsets returns every (dx, dy) within Chebyshev distance r of the
+// origin, excluding the origin itself
+func mooreOffsets(r int64) []Coord {
+	offsets := make([]Coord, 0, (2*r+1)*(2*r+1)-1)
+
+	for dx := -r; dx <= r; dx++ {
+		for dy := -r; dy <= r; dy++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			offsets = append(offsets, Coord{X: dx, Y: dy})
+		}
+	}
+
+	return offsets
+}
+
+// vonNeumannOffsets returns every (dx, dy) within Manhattan distance r of
+// the origin, excluding the origin itself
+func vonNeumannOffsets(r int64) []Coord {
+	offsets := make([]Coord, 0, 2*r*(r+1))
+
+	for dx := -r; dx <= r; dx++ {
+		for dy := -r; dy <= r; dy++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			if abs64(dx)+abs64(dy) > r {
+				continue
+			}
+
+			offsets = append(offsets, Coord{X: dx, Y: dy})
+		}
+	}
+
+	return offsets
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// CountInterval is an inclusive [Min, Max] range of live-neighbor counts,
+// used by Larger-than-Life birth/survival conditions
+type CountInterval struct {
+	Min, Max int
+}
+
+// Contains reports whether n falls within the interval
+func (interval CountInterval) Contains(n int) bool {
+	return n >= interval.Min && n <= interval.Max
+}
+
+func intervalsContain(intervals []CountInterval, n int) bool {
+	for _, interval := range intervals {
+		if interval.Contains(n) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StandardRule implements classic B/S Life: two states, a fixed Moore
+// radius-1 neighborhood, and discrete birth/survival neighbor counts
+type StandardRule struct {
+	Birth   []int
+	Survive []int
+}
+
+func (r *StandardRule) Neighbors() []Coord {
+	return mooreOffsets(1)
+}
+
+func (r *StandardRule) States() int {
+	return 2
+}
+
+func (r *StandardRule) NextState(state int, aliveNeighbors int) int {
+	check := r.Birth
+
+	if state == 1 {
+		check = r.Survive
+	}
+
+	for _, neighbors := range check {
+		if aliveNeighbors == neighbors {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// GenerationsRule implements Generations-style B.../S.../C rules: a cell
+// that doesn't survive passes through States-2 refractory "dying" states
+// (2..States-1) before returning to dead, instead of dying outright. Only
+// state 1 counts as alive for a neighbor's purposes.
+type GenerationsRule struct {
+	Birth      []int
+	Survive    []int
+	StateCount int
+}
+
+func (r *GenerationsRule) Neighbors() []Coord {
+	return mooreOffsets(1)
+}
+
+func (r *GenerationsRule) States() int {
+	return r.StateCount
+}
+
+func (r *GenerationsRule) NextState(state int, aliveNeighbors int) int {
+	if state == 0 {
+		for _, neighbors := range r.Birth {
+			if aliveNeighbors == neighbors {
+				return 1
+			}
+		}
+
+		return 0
+	}
+
+	if state == 1 {
+		for _, neighbors := range r.Survive {
+			if aliveNeighbors == neighbors {
+				return 1
+			}
+		}
+
+		if r.StateCount <= 2 {
+			return 0
+		}
+
+		return 2
+	}
+
+	// Refractory state: count down toward dead regardless of neighbors
+	if state+1 >= r.StateCount {
+		return 0
+	}
+
+	return state + 1
+}
+
+// LargerThanLifeRule generalizes the standard rule to an arbitrary
+// neighborhood radius and shape, with birth/survival given as inclusive
+// neighbor-count intervals rather than discrete counts
+type LargerThanLifeRule struct {
+	Birth      []CountInterval
+	Survive    []CountInterval
+	Range      int64
+	VonNeumann bool
+}
+
+func (r *LargerThanLifeRule) Neighbors() []Coord {
+	if r.VonNeumann {
+		return vonNeumannOffsets(r.Range)
+	}
+
+	return mooreOffsets(r.Range)
+}
+
+func (r *LargerThanLifeRule) States() int {
+	return 2
+}
+
+func (r *LargerThanLifeRule) NextState(state int, aliveNeighbors int) int {
+	if state == 1 {
+		if intervalsContain(r.Survive, aliveNeighbors) {
+			return 1
+		}
+
+		return 0
+	}
+
+	if intervalsContain(r.Birth, aliveNeighbors) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,120 @@
+package world
+
+import "testing"
+
+func TestStandardRuleNextState(t *testing.T) {
+	rule := &StandardRule{Birth: []int{3}, Survive: []int{2, 3}}
+
+	cases := []struct {
+		state, aliveNeighbors, want int
+	}{
+		{0, 3, 1}, // birth
+		{0, 2, 0}, // not enough neighbors to be born
+		{1, 2, 1}, // survives
+		{1, 3, 1}, // survives
+		{1, 1, 0}, // dies of loneliness
+		{1, 4, 0}, // dies of overcrowding
+	}
+
+	for _, c := range cases {
+		if got := rule.NextState(c.state, c.aliveNeighbors); got != c.want {
+			t.Errorf("NextState(%d, %d) = %d, want %d", c.state, c.aliveNeighbors, got, c.want)
+		}
+	}
+
+	if got := rule.States(); got != 2 {
+		t.Errorf("States() = %d, want 2", got)
+	}
+
+	if got := len(rule.Neighbors()); got != 8 {
+		t.Errorf("Neighbors() returned %d offsets, want 8", got)
+	}
+}
+
+func TestGenerationsRuleNextState(t *testing.T) {
+	rule := &GenerationsRule{Birth: []int{3}, Survive: []int{2, 3}, StateCount: 4}
+
+	if got := rule.NextState(0, 3); got != 1 {
+		t.Errorf("birth: NextState(0, 3) = %d, want 1", got)
+	}
+
+	if got := rule.NextState(1, 2); got != 1 {
+		t.Errorf("survive: NextState(1, 2) = %d, want 1", got)
+	}
+
+	if got := rule.NextState(1, 5); got != 2 {
+		t.Errorf("death enters refractory: NextState(1, 5) = %d, want 2", got)
+	}
+
+	if got := rule.NextState(2, 8); got != 3 {
+		t.Errorf("refractory counts down regardless of neighbors: NextState(2, 8) = %d, want 3", got)
+	}
+
+	if got := rule.NextState(3, 8); got != 0 {
+		t.Errorf("last refractory state returns to dead: NextState(3, 8) = %d, want 0", got)
+	}
+
+	if got := rule.States(); got != 4 {
+		t.Errorf("States() = %d, want 4", got)
+	}
+}
+
+func TestGenerationsRuleNoRefractoryStates(t *testing.T) {
+	rule := &GenerationsRule{Birth: []int{3}, Survive: []int{2, 3}, StateCount: 2}
+
+	if got := rule.NextState(1, 5); got != 0 {
+		t.Errorf("StateCount 2 has no refractory states: NextState(1, 5) = %d, want 0", got)
+	}
+}
+
+func TestLargerThanLifeRuleNextState(t *testing.T) {
+	rule := &LargerThanLifeRule{
+		Birth:   []CountInterval{{Min: 6, Max: 9}},
+		Survive: []CountInterval{{Min: 5, Max: 7}},
+		Range:   2,
+	}
+
+	if got := rule.NextState(0, 7); got != 1 {
+		t.Errorf("birth in range: NextState(0, 7) = %d, want 1", got)
+	}
+
+	if got := rule.NextState(0, 5); got != 0 {
+		t.Errorf("birth out of range: NextState(0, 5) = %d, want 0", got)
+	}
+
+	if got := rule.NextState(1, 6); got != 1 {
+		t.Errorf("survive in range: NextState(1, 6) = %d, want 1", got)
+	}
+
+	if got := rule.NextState(1, 9); got != 0 {
+		t.Errorf("survive out of range: NextState(1, 9) = %d, want 0", got)
+	}
+
+	if got := len(rule.Neighbors()); got != 24 {
+		t.Errorf("Moore range 2 Neighbors() returned %d offsets, want 24", got)
+	}
+}
+
+func TestLargerThanLifeRuleVonNeumannNeighbors(t *testing.T) {
+	rule := &LargerThanLifeRule{Range: 2, VonNeumann: true}
+
+	if got := len(rule.Neighbors()); got != 12 {
+		t.Errorf("von Neumann range 2 Neighbors() returned %d offsets, want 12", got)
+	}
+}
+
+func TestCountIntervalContains(t *testing.T) {
+	interval := CountInterval{Min: 3, Max: 5}
+
+	for _, n := range []int{3, 4, 5} {
+		if !interval.Contains(n) {
+			t.Errorf("Contains(%d) = false, want true", n)
+		}
+	}
+
+	for _, n := range []int{2, 6} {
+		if interval.Contains(n) {
+			t.Errorf("Contains(%d) = true, want false", n)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package world
+
+// RuleConfig collects every setting that can influence which Rule gets
+// built and how; see BuildRule.
+type RuleConfig struct {
+	Birth       []int
+	Survive     []int
+	Generations int // >= 3 selects a GenerationsRule
+
+	Range         int64 // > 1 selects a LargerThanLifeRule (unless Generations applies)
+	VonNeumann    bool
+	HasLTLBirth   bool
+	HasLTLSurvive bool
+	LTLBirth      []CountInterval
+	LTLSurvive    []CountInterval
+}
+
+// BuildRule constructs the Rule that cfg describes: a GenerationsRule when
+// Generations >= 3, a LargerThanLifeRule when a non-default range,
+// neighborhood, or LTL interval was configured, and a StandardRule
+// otherwise.
+func BuildRule(cfg RuleConfig) Rule {
+	if cfg.Generations >= 3 {
+		return &GenerationsRule{
+			Birth:      cfg.Birth,
+			Survive:    cfg.Survive,
+			StateCount: cfg.Generations,
+		}
+	}
+
+	if cfg.Range > 1 || cfg.VonNeumann || cfg.HasLTLBirth || cfg.HasLTLSurvive {
+		birth := cfg.LTLBirth
+		survive := cfg.LTLSurvive
+
+		if birth == nil {
+			birth = intsToIntervals(cfg.Birth)
+		}
+
+		if survive == nil {
+			survive = intsToIntervals(cfg.Survive)
+		}
+
+		rnge := cfg.Range
+
+		if rnge < 1 {
+			rnge = 1
+		}
+
+		return &LargerThanLifeRule{
+			Birth:      birth,
+			Survive:    survive,
+			Range:      rnge,
+			VonNeumann: cfg.VonNeumann,
+		}
+	}
+
+	return &StandardRule{
+		Birth:   cfg.Birth,
+		Survive: cfg.Survive,
+	}
+}
+
+func intsToIntervals(values []int) []CountInterval {
+	intervals := make([]CountInterval, 0, len(values))
+
+	for _, value := range values {
+		intervals = append(intervals, CountInterval{Min: value, Max: value})
+	}
+
+	return intervals
+}
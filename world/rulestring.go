@@ -0,0 +1,171 @@
+package world
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsedRuleString is the result of parsing a B/S or B/S/C rule string
+// (e.g. "B3/S23" or "B3/S23/C4") into its component parts
+type ParsedRuleString struct {
+	Birth       []int
+	Survive     []int
+	Generations int // 0 when the string had no "/C" component
+}
+
+// ParseRuleString parses a rule string in standard B/S notation (e.g.
+// "B3/S23", or "S23/B3"), optionally followed by a Generations state count
+// (e.g. "B3/S23/C3")
+func ParseRuleString(rule string) (ParsedRuleString, error) {
+	parts := strings.Split(rule, "/")
+
+	if len(parts) < 2 || len(parts) > 3 {
+		return ParsedRuleString{}, fmt.Errorf("unable to parse rule string %s", rule)
+	}
+
+	born, survives := parts[0], parts[1]
+
+	if strings.HasPrefix(strings.ToUpper(survives), "B") {
+		born, survives = survives, born
+	}
+
+	birth, err := parseRuleDigits(born, "B")
+
+	if err != nil {
+		return ParsedRuleString{}, err
+	}
+
+	survive, err := parseRuleDigits(survives, "S")
+
+	if err != nil {
+		return ParsedRuleString{}, err
+	}
+
+	parsed := ParsedRuleString{Birth: birth, Survive: survive}
+
+	if len(parts) == 3 {
+		states := strings.TrimSpace(parts[2])
+
+		if !strings.HasPrefix(strings.ToUpper(states), "C") {
+			return ParsedRuleString{}, fmt.Errorf("expected rule component %s to start with C", states)
+		}
+
+		generations, err := strconv.Atoi(states[1:])
+
+		if err != nil {
+			return ParsedRuleString{}, fmt.Errorf("unable to parse Generations state count %s: %w", states, err)
+		}
+
+		parsed.Generations = generations
+	}
+
+	return parsed, nil
+}
+
+// Parses the digits out of a single rule component (e.g. "B3" -> [3],
+// "S23" -> [2, 3]), checking it starts with the expected B/S prefix
+func parseRuleDigits(component string, prefix string) ([]int, error) {
+	component = strings.TrimSpace(component)
+
+	if !strings.HasPrefix(strings.ToUpper(component), prefix) {
+		return nil, fmt.Errorf("expected rule component %s to start with %s", component, prefix)
+	}
+
+	digits := component[len(prefix):]
+	neighbors := make([]int, 0, len(digits))
+
+	for _, digit := range digits {
+		neighbor, err := strconv.Atoi(string(digit))
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse rule digit %q in %s: %w", digit, component, err)
+		}
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors, nil
+}
+
+// FormatRuleString formats a rule back into "B3/S23" notation, with a
+// "/C3" suffix appended when generations > 2
+func FormatRuleString(birth []int, survive []int, generations int) string {
+	var born, survives strings.Builder
+
+	for _, neighbor := range birth {
+		fmt.Fprintf(&born, "%d", neighbor)
+	}
+
+	for _, neighbor := range survive {
+		fmt.Fprintf(&survives, "%d", neighbor)
+	}
+
+	rule := fmt.Sprintf("B%s/S%s", born.String(), survives.String())
+
+	if generations >= 3 {
+		rule = fmt.Sprintf("%s/C%d", rule, generations)
+	}
+
+	return rule
+}
+
+// ParseNeighborList parses a comma-delimited list of digits (e.g. "2,3" ->
+// [2, 3]); used by the -newlife/-exlife flags
+func ParseNeighborList(value string) ([]int, error) {
+	neighbors := []int{}
+
+	for _, part := range strings.Split(value, ",") {
+		neighbor, err := strconv.Atoi(strings.TrimSpace(part))
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse integer from neighbor list %s: %w", value, err)
+		}
+
+		if neighbor < 1 {
+			return nil, fmt.Errorf("neighbor integer %d must be greater than 0", neighbor)
+		}
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors, nil
+}
+
+// ParseIntervalList parses a comma-delimited list of counts or count ranges
+// (e.g. "6-9,12" -> [[6,9],[12,12]]) for Larger-than-Life birth/survival
+func ParseIntervalList(value string) ([]CountInterval, error) {
+	intervals := []CountInterval{}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+
+		if dash := strings.Index(part, "-"); dash > 0 {
+			min, err := strconv.Atoi(strings.TrimSpace(part[:dash]))
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse interval minimum from %s: %w", part, err)
+			}
+
+			max, err := strconv.Atoi(strings.TrimSpace(part[dash+1:]))
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse interval maximum from %s: %w", part, err)
+			}
+
+			intervals = append(intervals, CountInterval{Min: min, Max: max})
+
+			continue
+		}
+
+		n, err := strconv.Atoi(part)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse integer from interval list %s: %w", value, err)
+		}
+
+		intervals = append(intervals, CountInterval{Min: n, Max: n})
+	}
+
+	return intervals, nil
+}
@@ -0,0 +1,119 @@
+package world
+
+import "testing"
+
+func TestParseRuleStringBeforeSlash(t *testing.T) {
+	parsed, err := ParseRuleString("B3/S23")
+
+	if err != nil {
+		t.Fatalf("ParseRuleString(B3/S23) returned error: %v", err)
+	}
+
+	if len(parsed.Birth) != 1 || parsed.Birth[0] != 3 {
+		t.Errorf("Birth = %v, want [3]", parsed.Birth)
+	}
+
+	if len(parsed.Survive) != 2 || parsed.Survive[0] != 2 || parsed.Survive[1] != 3 {
+		t.Errorf("Survive = %v, want [2 3]", parsed.Survive)
+	}
+
+	if parsed.Generations != 0 {
+		t.Errorf("Generations = %d, want 0", parsed.Generations)
+	}
+}
+
+func TestParseRuleStringSurviveFirst(t *testing.T) {
+	parsed, err := ParseRuleString("S23/B3")
+
+	if err != nil {
+		t.Fatalf("ParseRuleString(S23/B3) returned error: %v", err)
+	}
+
+	if len(parsed.Birth) != 1 || parsed.Birth[0] != 3 {
+		t.Errorf("Birth = %v, want [3]", parsed.Birth)
+	}
+
+	if len(parsed.Survive) != 2 || parsed.Survive[0] != 2 || parsed.Survive[1] != 3 {
+		t.Errorf("Survive = %v, want [2 3]", parsed.Survive)
+	}
+}
+
+func TestParseRuleStringGenerations(t *testing.T) {
+	parsed, err := ParseRuleString("B3/S23/C5")
+
+	if err != nil {
+		t.Fatalf("ParseRuleString(B3/S23/C5) returned error: %v", err)
+	}
+
+	if parsed.Generations != 5 {
+		t.Errorf("Generations = %d, want 5", parsed.Generations)
+	}
+}
+
+func TestParseRuleStringErrors(t *testing.T) {
+	cases := []string{"B3", "B3/S23/D5", "X3/S23", "B3/S23/C5/C6"}
+
+	for _, rule := range cases {
+		if _, err := ParseRuleString(rule); err == nil {
+			t.Errorf("ParseRuleString(%s) succeeded, want error", rule)
+		}
+	}
+}
+
+func TestFormatRuleStringRoundTrip(t *testing.T) {
+	cases := []string{"B3/S23", "B3/S23/C5", "B36/S23"}
+
+	for _, rule := range cases {
+		parsed, err := ParseRuleString(rule)
+
+		if err != nil {
+			t.Fatalf("ParseRuleString(%s) returned error: %v", rule, err)
+		}
+
+		if got := FormatRuleString(parsed.Birth, parsed.Survive, parsed.Generations); got != rule {
+			t.Errorf("FormatRuleString round trip of %s = %s", rule, got)
+		}
+	}
+}
+
+func TestFormatRuleStringOmitsLowGenerationsSuffix(t *testing.T) {
+	if got := FormatRuleString([]int{3}, []int{2, 3}, 2); got != "B3/S23" {
+		t.Errorf("FormatRuleString with Generations=2 = %s, want B3/S23 (no /C suffix)", got)
+	}
+}
+
+func TestParseNeighborList(t *testing.T) {
+	neighbors, err := ParseNeighborList("2, 3")
+
+	if err != nil {
+		t.Fatalf("ParseNeighborList returned error: %v", err)
+	}
+
+	if len(neighbors) != 2 || neighbors[0] != 2 || neighbors[1] != 3 {
+		t.Errorf("neighbors = %v, want [2 3]", neighbors)
+	}
+
+	if _, err := ParseNeighborList("0"); err == nil {
+		t.Error("ParseNeighborList(0) succeeded, want error since neighbor counts must be > 0")
+	}
+}
+
+func TestParseIntervalList(t *testing.T) {
+	intervals, err := ParseIntervalList("6-9, 12")
+
+	if err != nil {
+		t.Fatalf("ParseIntervalList returned error: %v", err)
+	}
+
+	if len(intervals) != 2 {
+		t.Fatalf("got %d intervals, want 2", len(intervals))
+	}
+
+	if intervals[0] != (CountInterval{Min: 6, Max: 9}) {
+		t.Errorf("intervals[0] = %v, want {6 9}", intervals[0])
+	}
+
+	if intervals[1] != (CountInterval{Min: 12, Max: 12}) {
+		t.Errorf("intervals[1] = %v, want {12 12}", intervals[1])
+	}
+}
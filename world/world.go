@@ -0,0 +1,229 @@
+// Package world holds the data model shared by every engine and format in
+// game-of-life: the cell grid itself (World), its coordinate and bounding
+// types (Coord, Bounds), and the Rule interface that decides how a cell's
+// state evolves from one generation to the next.
+package world
+
+// Coord is a single cell's position in the world
+type Coord struct {
+	X, Y int64
+}
+
+// Bounds is an inclusive rectangular region of the world
+type Bounds struct {
+	MinX, MaxX, MinY, MaxY int64
+}
+
+// Contains reports whether coord falls within b
+func (b Bounds) Contains(coord Coord) bool {
+	return coord.X >= b.MinX && coord.X <= b.MaxX && coord.Y >= b.MinY && coord.Y <= b.MaxY
+}
+
+// Size returns the width and height of b
+func (b Bounds) Size() (width, height int64) {
+	return b.MaxX - b.MinX + 1, b.MaxY - b.MinY + 1
+}
+
+// Rule decides how a cell transitions between generations: which offsets
+// count as its neighborhood, how many distinct states it can be in, and
+// what its next state is given its current state and live-neighbor count.
+type Rule interface {
+	// NextState returns the state a cell in the given state should move to,
+	// given how many of its neighbors are alive (state == 1)
+	NextState(state int, aliveNeighbors int) int
+
+	// Neighbors returns the (dx, dy) offsets that count toward a cell's
+	// live-neighbor total
+	Neighbors() []Coord
+
+	// States returns how many distinct states a cell can be in (2 for the
+	// standard and Larger-than-Life rules, C for Generations)
+	States() int
+}
+
+// World is the live cell grid: a sparse map of state by (x, y), together
+// with the bounds it's confined to and whether those bounds wrap around.
+type World struct {
+	Bounds     Bounds
+	Wraparound bool
+
+	cells map[int64]map[int64]int
+}
+
+// New returns an empty World with the given bounds
+func New(bounds Bounds, wraparound bool) *World {
+	return &World{
+		Bounds:     bounds,
+		Wraparound: wraparound,
+		cells:      make(map[int64]map[int64]int),
+	}
+}
+
+// Get returns the state at (x, y), or 0 (dead) if it's never been set
+func (w *World) Get(x, y int64) int {
+	if coordYs, ok := w.cells[x]; ok {
+		if value, ok := coordYs[y]; ok {
+			return value
+		}
+	}
+
+	return 0
+}
+
+// EnsureStubbed guarantees (x, y) has an entry in the world, defaulting it
+// to dead (0) if it isn't already present
+func (w *World) EnsureStubbed(x, y int64) {
+	if _, ok := w.cells[x]; !ok {
+		w.cells[x] = make(map[int64]int)
+	}
+
+	if _, ok := w.cells[x][y]; !ok {
+		w.cells[x][y] = 0
+	}
+}
+
+// Set stores state at (x, y), stubbing out every neighbor coordinate (per
+// rule.Neighbors()) for ease of traversal later. Used while stepping the
+// simulation, once the active rule is already settled.
+func (w *World) Set(x, y int64, state int, rule Rule) {
+	w.EnsureStubbed(x, y)
+
+	for _, offset := range rule.Neighbors() {
+		neighborX, xExists := w.Neighbor(x, offset.X, true)
+
+		if !xExists {
+			continue
+		}
+
+		neighborY, yExists := w.Neighbor(y, offset.Y, false)
+
+		if !yExists {
+			continue
+		}
+
+		w.EnsureStubbed(neighborX, neighborY)
+	}
+
+	w.cells[x][y] = state
+}
+
+// SetRaw stores state at (x, y) without stubbing any neighbors. Used while
+// decoding a pattern file, before the active rule (and so its neighborhood
+// shape) has been settled; call StubNeighbors once decoding is done and the
+// rule is known.
+func (w *World) SetRaw(x, y int64, state int) {
+	w.EnsureStubbed(x, y)
+
+	w.cells[x][y] = state
+}
+
+// StubNeighbors ensures every neighbor (per rule.Neighbors()) of every
+// currently non-dead cell has a stubbed (at least dead) entry, so the
+// simulation can consider it for birth on the first tick. Call this once,
+// after decoding and after the active rule has been settled.
+func (w *World) StubNeighbors(rule Rule) {
+	live := []Coord{}
+
+	w.Each(func(x, y int64, state int) {
+		if state != 0 {
+			live = append(live, Coord{X: x, Y: y})
+		}
+	})
+
+	for _, coord := range live {
+		for _, offset := range rule.Neighbors() {
+			neighborX, xExists := w.Neighbor(coord.X, offset.X, true)
+
+			if !xExists {
+				continue
+			}
+
+			neighborY, yExists := w.Neighbor(coord.Y, offset.Y, false)
+
+			if !yExists {
+				continue
+			}
+
+			w.EnsureStubbed(neighborX, neighborY)
+		}
+	}
+}
+
+// Neighbor returns the neighbor of coord offset by delta along one axis
+// (xAxis selects World.Bounds.MinX/MaxX, otherwise MinY/MaxY), honoring
+// Wraparound, and false if that neighbor falls outside the world and
+// wraparound doesn't apply
+func (w *World) Neighbor(coord int64, delta int64, xAxis bool) (int64, bool) {
+	min, max := w.Bounds.MinX, w.Bounds.MaxX
+
+	if !xAxis {
+		min, max = w.Bounds.MinY, w.Bounds.MaxY
+	}
+
+	next := coord + delta
+
+	if next >= min && next <= max {
+		return next, true
+	}
+
+	if !w.Wraparound {
+		return 0, false
+	}
+
+	size := max - min + 1
+
+	if size <= 0 {
+		// The world is effectively infinite (e.g. the default min/max int64
+		// bounds); wraparound can't apply and this neighbor is simply out
+		// of range
+		return 0, false
+	}
+
+	offset := ((next-min)%size + size) % size
+
+	return min + offset, true
+}
+
+// Each invokes visit for every coordinate stubbed in the world, alive or
+// not, in unspecified order
+func (w *World) Each(visit func(x, y int64, state int)) {
+	for x, coordYs := range w.cells {
+		for y, state := range coordYs {
+			visit(x, y, state)
+		}
+	}
+}
+
+// LiveBounds returns the bounding box of every non-dead cell (state != 0);
+// empty is true when there are no such cells at all
+func (w *World) LiveBounds() (bounds Bounds, empty bool) {
+	empty = true
+
+	w.Each(func(x, y int64, state int) {
+		if state == 0 {
+			return
+		}
+
+		if empty {
+			bounds = Bounds{MinX: x, MaxX: x, MinY: y, MaxY: y}
+			empty = false
+
+			return
+		}
+
+		if x < bounds.MinX {
+			bounds.MinX = x
+		}
+		if x > bounds.MaxX {
+			bounds.MaxX = x
+		}
+		if y < bounds.MinY {
+			bounds.MinY = y
+		}
+		if y > bounds.MaxY {
+			bounds.MaxY = y
+		}
+	})
+
+	return
+}